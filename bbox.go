@@ -0,0 +1,121 @@
+/*
+Purpose:
+- Bounding box and compass direction for grid cells (Geohash and other cell based systems)
+
+Description:
+- BoundingBox holds the south-west and north-east corner of a cell in Lon Lat (WGS84).
+- Direction holds the eight compass directions used for adjacent cells.
+
+Remarks:
+- Bounding boxes do not cross the antimeridian (West <= East).
+*/
+
+package coco
+
+import (
+	"fmt"
+)
+
+// BoundingBox defines a Lon Lat rectangle (south-west and north-east corner)
+type BoundingBox struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// Direction defines a compass direction (e.g. for adjacent cells)
+type Direction int
+
+// compass directions
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+// Directions holds all compass directions (clockwise, starting north)
+var Directions = []Direction{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+
+/*
+String returns stringified BoundingBox object (south west north east, precision 0.11 meter).
+*/
+func (bbox BoundingBox) String() string {
+
+	return fmt.Sprintf("%.6f %.6f %.6f %.6f", bbox.South, bbox.West, bbox.North, bbox.East)
+}
+
+/*
+Center returns the center of the bounding box.
+*/
+func (bbox BoundingBox) Center() LL {
+
+	return LL{Lat: (bbox.South + bbox.North) / 2, Lon: (bbox.West + bbox.East) / 2}
+}
+
+/*
+Contains reports whether the position lies inside the bounding box (south and west edge included).
+*/
+func (bbox BoundingBox) Contains(ll LL) bool {
+
+	return ll.Lat >= bbox.South && ll.Lat < bbox.North && ll.Lon >= bbox.West && ll.Lon < bbox.East
+}
+
+/*
+String returns the abbreviation of the compass direction (e.g. "NE").
+*/
+func (direction Direction) String() string {
+
+	switch direction {
+	case North:
+		return "N"
+	case NorthEast:
+		return "NE"
+	case East:
+		return "E"
+	case SouthEast:
+		return "SE"
+	case South:
+		return "S"
+	case SouthWest:
+		return "SW"
+	case West:
+		return "W"
+	case NorthWest:
+		return "NW"
+	}
+
+	return fmt.Sprintf("Direction(%d)", int(direction))
+}
+
+/*
+offset returns the cell offset (-1, 0, +1) of the compass direction in latitude and longitude.
+*/
+func (direction Direction) offset() (int, int) {
+
+	switch direction {
+	case North:
+		return 1, 0
+	case NorthEast:
+		return 1, 1
+	case East:
+		return 0, 1
+	case SouthEast:
+		return -1, 1
+	case South:
+		return -1, 0
+	case SouthWest:
+		return -1, -1
+	case West:
+		return 0, -1
+	case NorthWest:
+		return 1, -1
+	}
+
+	return 0, 0
+}
@@ -0,0 +1,53 @@
+/*
+Purpose:
+- Bounding box and compass direction for grid cells (Geohash and other cell based systems)
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBoundingBox_Contains(t *testing.T) {
+
+	bbox := BoundingBox{South: 51, West: 7, North: 52, East: 8}
+
+	var tests = []struct {
+		ll       LL   // in
+		contains bool // out
+	}{
+		{LL{Lat: 51.5, Lon: 7.5}, true},
+		{LL{Lat: 51, Lon: 7}, true},    // south-west corner included
+		{LL{Lat: 52, Lon: 7.5}, false}, // north edge excluded
+		{LL{Lat: 51.5, Lon: 8}, false}, // east edge excluded
+		{LL{Lat: 50.9, Lon: 7.5}, false},
+	}
+
+	for _, test := range tests {
+		contains := bbox.Contains(test.ll)
+		function := fmt.Sprintf("%s.Contains(%s)", bbox, test.ll)
+		if contains != test.contains {
+			t.Errorf("\n%s -> %v != %v\n", function, contains, test.contains)
+		}
+	}
+
+	got := fmt.Sprintf("%s", bbox.Center())
+	want := "51.500000 7.500000"
+	if got != want {
+		t.Errorf("\n%s.Center() -> %s != %s\n", bbox, got, want)
+	}
+}
+
+func TestDirection_String(t *testing.T) {
+
+	got := fmt.Sprintf("%v %v", Directions, Direction(-1))
+	want := "[N NE E SE S SW W NW] Direction(-1)"
+	if got != want {
+		t.Errorf("\nDirection.String() -> %s != %s\n", got, want)
+	}
+}
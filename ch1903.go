@@ -0,0 +1,277 @@
+/*
+Purpose:
+- Swiss coordinates CH1903 (LV03) and CH1903+ (LV95) <-> Lon Lat, MGRS
+
+Description:
+- Swiss oblique conformal cylindrical projection (oblique Mercator) on the Bessel 1841 ellipsoid,
+  rigorous forward and inverse formulas according to swisstopo.
+- LV95: E 2'600'000 / N 1'200'000 at the origin (Bern), LV03: y 600'000 / x 200'000 at the origin.
+
+Remarks:
+- Datum shift CH1903/CH1903+ -> CHTRS95 (ETRS89 compatible): translation 674.374, 15.056, 405.346 m
+  (accuracy about 1 m, the official FINELTRA/CHENyx06 transformation is not supported).
+- LV03 <-> LV95 is converted by the false easting/northing only (differences up to 1.6 m to FINELTRA).
+
+Links:
+- https://www.swisstopo.admin.ch/en/knowledge-facts/surveying-geodesy/reference-frames/local/lv95.html
+- https://epsg.io/2056
+- https://epsg.io/21781
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+)
+
+// CH1903Frame defines the Swiss reference frame (false easting/northing convention)
+type CH1903Frame int
+
+// Swiss reference frames
+const (
+	LV95 CH1903Frame = iota // CH1903+, E 2'600'000 / N 1'200'000 (default)
+	LV03                    // CH1903, y 600'000 / x 200'000
+)
+
+// CH1903 defines coordinate in the Swiss coordinate system (LV95 or LV03)
+type CH1903 struct {
+	Frame    CH1903Frame
+	Easting  float64 // E (LV95) or y (LV03)
+	Northing float64 // N (LV95) or x (LV03)
+}
+
+// Swiss projection constants (Bessel 1841, projection center Bern 46°57'08.66" 7°26'22.50")
+var (
+	ch1903Lat0 = degToRad(46.0 + 57.0/60 + 8.66/3600)
+	ch1903Lon0 = degToRad(7.0 + 26.0/60 + 22.50/3600)
+)
+
+/*
+String returns the name of the Swiss reference frame.
+*/
+func (frame CH1903Frame) String() string {
+
+	switch frame {
+	case LV95:
+		return "LV95"
+	case LV03:
+		return "LV03"
+	}
+
+	return fmt.Sprintf("CH1903Frame(%d)", int(frame))
+}
+
+/*
+offsets returns the false easting and false northing of the reference frame.
+*/
+func (frame CH1903Frame) offsets() (float64, float64) {
+
+	if frame == LV03 {
+		return 600000, 200000
+	}
+
+	return 2600000, 1200000
+}
+
+/*
+String returns stringified CH1903 object (rounded to meters).
+*/
+func (ch CH1903) String() string {
+
+	return fmt.Sprintf("%s %.0f %.0f", ch.Frame, roundValue(ch.Easting, 1, RoundHalfUp), roundValue(ch.Northing, 1, RoundHalfUp))
+}
+
+// ch1903Constants holds the derived constants of the Swiss projection
+type ch1903Constants struct {
+	e     float64 // first eccentricity
+	R     float64 // radius of the projection sphere
+	alpha float64 // ratio sphere/ellipsoid longitudes
+	b0    float64 // latitude of the fundamental point on the sphere
+	K     float64 // latitude constant
+}
+
+/*
+newCH1903Constants calculates the constants of the Swiss projection.
+*/
+func newCH1903Constants() ch1903Constants {
+
+	ellipsoid := EllipsoidBessel1841
+	e2 := ellipsoid.E2()
+	e := math.Sqrt(e2)
+	sinLat0 := math.Sin(ch1903Lat0)
+	cosLat0 := math.Cos(ch1903Lat0)
+
+	c := ch1903Constants{e: e}
+	c.R = ellipsoid.A * math.Sqrt(1-e2) / (1 - e2*sinLat0*sinLat0)
+	c.alpha = math.Sqrt(1 + e2/(1-e2)*math.Pow(cosLat0, 4))
+	c.b0 = math.Asin(sinLat0 / c.alpha)
+	c.K = math.Log(math.Tan(math.Pi/4+c.b0/2)) - c.alpha*math.Log(math.Tan(math.Pi/4+ch1903Lat0/2)) +
+		c.alpha*e/2*math.Log((1+e*sinLat0)/(1-e*sinLat0))
+
+	return c
+}
+
+/*
+ch1903Forward projects Lon Lat (CH1903 datum, degrees) to Y and X (meters, relative to the projection center).
+*/
+func ch1903Forward(lat, lon float64) (float64, float64) {
+
+	c := newCH1903Constants()
+	latRad := degToRad(lat)
+	esin := c.e * math.Sin(latRad)
+
+	// ellipsoid -> sphere
+	S := c.alpha*math.Log(math.Tan(math.Pi/4+latRad/2)) - c.alpha*c.e/2*math.Log((1+esin)/(1-esin)) + c.K
+	b := 2 * (math.Atan(math.Exp(S)) - math.Pi/4)
+	l := c.alpha * (degToRad(lon) - ch1903Lon0)
+
+	// equator system -> pseudo equator system (rotation)
+	lBar := math.Atan(math.Sin(l) / (math.Sin(c.b0)*math.Tan(b) + math.Cos(c.b0)*math.Cos(l)))
+	bBar := math.Asin(math.Cos(c.b0)*math.Sin(b) - math.Sin(c.b0)*math.Cos(b)*math.Cos(l))
+
+	// sphere -> plane (Mercator)
+	Y := c.R * lBar
+	X := c.R / 2 * math.Log((1+math.Sin(bBar))/(1-math.Sin(bBar)))
+
+	return Y, X
+}
+
+/*
+ch1903Inverse projects Y and X (meters, relative to the projection center) to Lon Lat (CH1903 datum, degrees).
+*/
+func ch1903Inverse(Y, X float64) (float64, float64) {
+
+	c := newCH1903Constants()
+
+	// plane -> sphere
+	lBar := Y / c.R
+	bBar := 2 * (math.Atan(math.Exp(X/c.R)) - math.Pi/4)
+
+	// pseudo equator system -> equator system
+	b := math.Asin(math.Cos(c.b0)*math.Sin(bBar) + math.Sin(c.b0)*math.Cos(bBar)*math.Cos(lBar))
+	l := math.Atan(math.Sin(lBar) / (math.Cos(c.b0)*math.Cos(lBar) - math.Sin(c.b0)*math.Tan(bBar)))
+
+	// sphere -> ellipsoid (iteration)
+	lon := ch1903Lon0 + l/c.alpha
+	lat := b
+	for i := 0; i < 20; i++ {
+		S := (math.Log(math.Tan(math.Pi/4+b/2))-c.K)/c.alpha + c.e*math.Log(math.Tan(math.Pi/4+math.Asin(c.e*math.Sin(lat))/2))
+		next := 2*math.Atan(math.Exp(S)) - math.Pi/2
+		if math.Abs(next-lat) < 1e-14 {
+			lat = next
+			break
+		}
+		lat = next
+	}
+
+	return radToDeg(lat), radToDeg(lon)
+}
+
+/*
+ToCH1903 converts Lon Lat (WGS84 datum) to Swiss coordinates.
+frame holds the wanted reference frame (LV95 or LV03).
+*/
+func (ll LL) ToCH1903(frame CH1903Frame) (CH1903, error) {
+
+	return ll.ToCH1903Datum(frame, WGS84)
+}
+
+/*
+ToCH1903Datum converts Lon Lat to Swiss coordinates.
+frame holds the wanted reference frame (LV95 or LV03).
+datum holds the datum of the Lon Lat coordinate (e.g. WGS84 or CH1903Datum).
+*/
+func (ll LL) ToCH1903Datum(frame CH1903Frame, datum Datum) (CH1903, error) {
+
+	if frame != LV95 && frame != LV03 {
+		return CH1903{}, fmt.Errorf("invalid reference frame, frame = %v", frame)
+	}
+	if ll.Lon < -180 || ll.Lon > 180 {
+		return CH1903{}, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if ll.Lat < -90 || ll.Lat > 90 {
+		return CH1903{}, fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	llCH1903 := ll.Transform(datum, CH1903Datum)
+	Y, X := ch1903Forward(llCH1903.Lat, llCH1903.Lon)
+	if math.IsNaN(Y) || math.IsNaN(X) || math.Abs(Y) >= 1000000 || math.Abs(X) >= 1000000 {
+		return CH1903{}, fmt.Errorf("position outside of swiss coordinate system, ll = %s", ll)
+	}
+	falseEasting, falseNorthing := frame.offsets()
+
+	return CH1903{Frame: frame, Easting: Y + falseEasting, Northing: X + falseNorthing}, nil
+}
+
+/*
+ToLL converts Swiss coordinates to Lon Lat (WGS84 datum).
+*/
+func (ch CH1903) ToLL() (LL, error) {
+
+	return ch.ToLLDatum(WGS84)
+}
+
+/*
+ToLLDatum converts Swiss coordinates to Lon Lat.
+datum holds the wanted datum of the Lon Lat coordinate (e.g. WGS84 or CH1903Datum).
+*/
+func (ch CH1903) ToLLDatum(datum Datum) (LL, error) {
+
+	if ch.Frame != LV95 && ch.Frame != LV03 {
+		return LL{}, fmt.Errorf("invalid reference frame, frame = %v", ch.Frame)
+	}
+
+	// LV03 and LV95 numbers are mixed up easily, they differ by 2'000'000 / 1'000'000
+	falseEasting, falseNorthing := ch.Frame.offsets()
+	Y := ch.Easting - falseEasting
+	X := ch.Northing - falseNorthing
+	if math.Abs(Y) >= 1000000 || math.Abs(X) >= 1000000 {
+		return LL{}, fmt.Errorf("coordinate does not match reference frame %s, ch1903 = %s", ch.Frame, ch)
+	}
+
+	ll := LL{}
+	ll.Lat, ll.Lon = ch1903Inverse(Y, X)
+
+	return ll.Transform(CH1903Datum, datum), nil
+}
+
+/*
+ToFrame converts Swiss coordinates into the given reference frame (LV03 <-> LV95, false easting/northing only).
+frame holds the wanted reference frame (LV95 or LV03).
+*/
+func (ch CH1903) ToFrame(frame CH1903Frame) CH1903 {
+
+	fromEasting, fromNorthing := ch.Frame.offsets()
+	toEasting, toNorthing := frame.offsets()
+
+	return CH1903{Frame: frame, Easting: ch.Easting - fromEasting + toEasting, Northing: ch.Northing - fromNorthing + toNorthing}
+}
+
+/*
+ToMGRS converts Swiss coordinates to MGRS/UTMREF (WGS84).
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+*/
+func (ch CH1903) ToMGRS(accuracy int) (MGRS, error) {
+
+	ll, err := ch.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%v> at ch.ToLL(), ch1903 = %s", err, ch)
+	}
+
+	return ll.ToMGRS(accuracy)
+}
+
+/*
+ToCH1903 converts MGRS/UTMREF (WGS84) to Swiss coordinates (south-west corner of the MGRS cell).
+frame holds the wanted reference frame (LV95 or LV03).
+*/
+func (mgrs MGRS) ToCH1903(frame CH1903Frame) (CH1903, error) {
+
+	ll, _, err := mgrs.ToLL()
+	if err != nil {
+		return CH1903{}, fmt.Errorf("error <%w> at mgrs.ToLL(), mgrs = %s", err, mgrs)
+	}
+
+	return ll.ToCH1903(frame)
+}
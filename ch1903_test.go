@@ -0,0 +1,159 @@
+/*
+Purpose:
+- Swiss coordinates CH1903 (LV03) and CH1903+ (LV95) <-> Lon Lat, MGRS
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"testing"
+)
+
+func TestLL_ToCH1903(t *testing.T) {
+
+	var tests = []struct {
+		ll    LL          // in
+		frame CH1903Frame // in
+		ch    CH1903      // out
+		err   error       // out
+	}{
+		// positive tests
+		{LL{Lat: 46.044130, Lon: 8.730497}, LV95, CH1903{Frame: LV95, Easting: 2700000.0, Northing: 1100000.0}, nil}, // swisstopo example
+		{LL{Lat: 46.044130, Lon: 8.730497}, LV03, CH1903{Frame: LV03, Easting: 700000.0, Northing: 100000.0}, nil},
+		{LL{Lat: 46.948000, Lon: 7.447400}, LV95, CH1903{Frame: LV95, Easting: 2600667.5, Northing: 1199657.3}, nil}, // Bern
+		{LL{Lat: 47.376900, Lon: 8.541700}, LV95, CH1903{Frame: LV95, Easting: 2683303.9, Northing: 1247925.6}, nil}, // Zürich
+		{LL{Lat: 46.204400, Lon: 6.143200}, LV03, CH1903{Frame: LV03, Easting: 500016.0, Northing: 117821.1}, nil},   // Genève
+		// negative tests
+		{LL{Lat: 46.9, Lon: 7.4}, CH1903Frame(2), CH1903{}, fmt.Errorf("invalid reference frame, frame = CH1903Frame(2)")},
+		{LL{Lat: -33.9, Lon: 151.2}, LV95, CH1903{}, fmt.Errorf("position outside of swiss coordinate system, ll = -33.900000 151.200000")},
+	}
+
+	for _, test := range tests {
+		ch, err := test.ll.ToCH1903(test.frame)
+		function := fmt.Sprintf("%#v.ToCH1903(%s)", test.ll, test.frame)
+		got := fmt.Sprintf("%s %.1f %.1f %v", ch.Frame, ch.Easting, ch.Northing, err)
+		want := fmt.Sprintf("%s %.1f %.1f %v", test.ch.Frame, test.ch.Easting, test.ch.Northing, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		// round trip
+		if err == nil {
+			ll, err := ch.ToLL()
+			got = fmt.Sprintf("%s %v", ll, err)
+			want = fmt.Sprintf("%s %v", test.ll, nil)
+			if got != want {
+				t.Errorf("\n%#v.ToLL() -> %s != %s\n", ch, got, want)
+			}
+		}
+	}
+}
+
+func TestCH1903_ToLL(t *testing.T) {
+
+	var tests = []struct {
+		ch   CH1903 // in
+		ll   LL     // out
+		mgrs MGRS   // out
+		err  error  // out
+	}{
+		// positive tests
+		{CH1903{Frame: LV95, Easting: 2600000, Northing: 1200000}, LL{Lat: 46.951083, Lon: 7.438632}, "32TLT8118800911", nil}, // projection center
+		{CH1903{Frame: LV03, Easting: 600000, Northing: 200000}, LL{Lat: 46.951083, Lon: 7.438632}, "32TLT8118800911", nil},
+		{CH1903{Frame: LV95, Easting: 2683304, Northing: 1247926}, LL{Lat: 47.376903, Lon: 8.541701}, "32TMT6540347151", nil},
+		// negative tests
+		{CH1903{Frame: LV03, Easting: 2600000, Northing: 1200000}, LL{}, "", fmt.Errorf("coordinate does not match reference frame LV03, ch1903 = LV03 2600000 1200000")},
+		{CH1903{Frame: CH1903Frame(-1), Easting: 600000, Northing: 200000}, LL{}, "", fmt.Errorf("invalid reference frame, frame = CH1903Frame(-1)")},
+	}
+
+	for _, test := range tests {
+		ll, err := test.ch.ToLL()
+		function := fmt.Sprintf("%#v.ToLL()", test.ch)
+		got := fmt.Sprintf("%s %v", ll, err)
+		want := fmt.Sprintf("%s %v", test.ll, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+		if err != nil {
+			continue
+		}
+
+		mgrs, err := test.ch.ToMGRS(1)
+		function = fmt.Sprintf("%#v.ToMGRS(1)", test.ch)
+		got = fmt.Sprintf("%s %v", mgrs, err)
+		want = fmt.Sprintf("%s %v", test.mgrs, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestCH1903_ToFrame(t *testing.T) {
+
+	var tests = []struct {
+		ch    CH1903      // in
+		frame CH1903Frame // in
+		want  CH1903      // out
+	}{
+		{CH1903{Frame: LV03, Easting: 683304, Northing: 247926}, LV95, CH1903{Frame: LV95, Easting: 2683304, Northing: 1247926}},
+		{CH1903{Frame: LV95, Easting: 2683304, Northing: 1247926}, LV03, CH1903{Frame: LV03, Easting: 683304, Northing: 247926}},
+		{CH1903{Frame: LV95, Easting: 2683304, Northing: 1247926}, LV95, CH1903{Frame: LV95, Easting: 2683304, Northing: 1247926}},
+	}
+
+	for _, test := range tests {
+		ch := test.ch.ToFrame(test.frame)
+		function := fmt.Sprintf("%#v.ToFrame(%s)", test.ch, test.frame)
+		got := fmt.Sprintf("%s", ch)
+		want := fmt.Sprintf("%s", test.want)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMGRS_ToCH1903(t *testing.T) {
+
+	var tests = []struct {
+		mgrs  MGRS        // in
+		frame CH1903Frame // in
+		ch    string      // out
+		err   error       // out
+	}{
+		// positive tests
+		{"32TMT6540347150", LV95, "LV95 2683304 1247925", nil},
+		{"32TLT8118800911", LV03, "LV03 599999 200000", nil},
+		// negative tests
+		{"32TMT654", LV95, "LV95 0 0", fmt.Errorf("error <error <uneven number of digits (3) at position 8, mgrs = 32TMT654> at mgrs.ToUTM()> at mgrs.ToLL(), mgrs = 32TMT654")},
+	}
+
+	for _, test := range tests {
+		ch, err := test.mgrs.ToCH1903(test.frame)
+		function := fmt.Sprintf("%s.ToCH1903(%s)", test.mgrs, test.frame)
+		got := fmt.Sprintf("%s %v", ch, err)
+		want := fmt.Sprintf("%s %v", test.ch, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleLL_ToCH1903() {
+
+	ll := LL{Lat: 46.948000, Lon: 7.447400}
+	ch, err := ll.ToCH1903(LV95)
+	if err != nil {
+		log.Printf("error <%v> at ll.ToCH1903()", err)
+		return
+	}
+	fmt.Println(ch)
+	fmt.Println(ch.ToFrame(LV03))
+
+	// Output:
+	// LV95 2600667 1199657
+	// LV03 600667 199657
+}
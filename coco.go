@@ -56,22 +56,244 @@ Links:
 Package coco (coordinate conversion) provides methods for converting coordinates between WGS84 Lon Lat, UTM and MGRS/UTMREF.
 
 Supported conversions:
-  utm.ToLL()   : converts from UTM to LL
-  utm.ToMGRS() : converts from UTM to MGRS
-  ll.ToUTM()   : converts from LL to UTM
-  ll.ToMGRS()  : converts from LL to MGRS
-  mgrs.ToUTM() : converts from MGRS to UTM
-  mgrs.ToLL()  : converts from MGRS to LL
+  utm.ToLL()      : converts from UTM to LL
+  utm.ToMGRS()    : converts from UTM to MGRS
+  ll.ToUTM()      : converts from LL to UTM
+  ll.ToMGRS()     : converts from LL to MGRS
+  mgrs.ToUTM()    : converts from MGRS to UTM
+  mgrs.ToLL()     : converts from MGRS to LL
+  ups.ToLL()      : converts from UPS to LL
+  ups.ToMGRS()    : converts from UPS to MGRS
+  ll.ToUPS()      : converts from LL to UPS
+  mgrs.ToUPS()    : converts from MGRS to UPS
+  ll.ToUTMZone()  : converts from LL to UTM in a caller-specified zone and hemisphere
+  utm.ToZone()    : re-projects UTM into another (e.g. neighbouring) zone
+  ParseMGRS()     : parses MGRS into components (lenient, errors with character position)
+  mgrs.Parse()    : parses MGRS into components
+  mgrs.Validate() : checks that the MGRS 100k square exists in its grid zone (ErrSquareNotInZone)
+
+  UTM notation (latitude band "32U" or hemisphere "32N"):
+  ParseUTM()             : parses UTM in band or hemisphere notation (ambiguous "S" must be resolved)
+  NewUTMHemisphere()     : creates UTM (band letter) from hemisphere notation
+  utm.Hemisphere()       : returns the hemisphere of UTM
+  utm.HemisphereString() : formats UTM in hemisphere notation
+  utm.FormatHemisphere() : formats UTM in hemisphere notation with given decimals and rounding
+
+Ellipsoid-aware conversions (the methods above are WGS84 shortcuts):
+  utm.ToLLEllipsoid()     : converts from UTM to LL
+  ll.ToUTMEllipsoid()     : converts from LL to UTM
+  ll.ToMGRSEllipsoid()    : converts from LL to MGRS
+  mgrs.ToLLEllipsoid()    : converts from MGRS to LL
+  ups.ToLLEllipsoid()     : converts from UPS to LL
+  ll.ToUPSEllipsoid()     : converts from LL to UPS
+  utm.ToLLEngine()        : converts from UTM to LL (selectable transverse Mercator engine)
+  ll.ToUTMEngine()        : converts from LL to UTM (selectable transverse Mercator engine)
+  ll.ToUTMZoneEllipsoid() : converts from LL to UTM in a caller-specified zone
+  utm.ToZoneEllipsoid()   : re-projects UTM into another zone
+
+Datum transformations (through geocentric ECEF, Helmert 7-parameter or Molodensky-Badekas):
+  ll.Transform()             : converts LL from one datum to another, e.g. LL{...}.Transform(DHDN, WGS84)
+  ll.TransformHeight()       : converts LL and ellipsoidal height from one datum to another
+  ll.ToECEF()                : converts from LL (and height) to ECEF
+  ecef.ToLL()                : converts from ECEF to LL (and height)
+  helmert.Transform()        : applies a Helmert/Molodensky-Badekas transformation to ECEF
+  helmert.InverseTransform() : applies the exact inverse transformation to ECEF
+  DatumByName()              : returns a built-in datum by name or alias
+  DatumNames()               : returns the names of all built-in datums
+
+British National Grid (OSGB36, grid references "TQ 30080 80500"):
+  ll.ToOSGB()        : converts from LL (WGS84) to OSGB
+  ll.ToOSGBDatum()   : converts from LL (given datum, e.g. OSGB36) to OSGB
+  osgb.ToLL()        : converts from OSGB to LL (WGS84)
+  osgb.ToLLDatum()   : converts from OSGB to LL (given datum)
+  osgb.GridRef()     : formats OSGB as grid reference with 2 to 10 digits
+  ParseOSGBGridRef() : parses a grid reference (2 to 10 digits) into OSGB
+
+Irish Grid (TM65, grid references "O 15899 34671") and Irish Transverse Mercator (ITM, IRENET95):
+  ll.ToIrishGrid()      : converts from LL (WGS84) to Irish Grid
+  ll.ToIrishGridDatum() : converts from LL (given datum, e.g. TM65) to Irish Grid
+  irishGrid.ToLL()      : converts from Irish Grid to LL (WGS84)
+  irishGrid.ToLLDatum() : converts from Irish Grid to LL (given datum)
+  irishGrid.GridRef()   : formats Irish Grid as grid reference with 2 to 10 digits
+  ParseIrishGridRef()   : parses a grid reference (2 to 10 digits) into Irish Grid
+  ll.ToITM()            : converts from LL (WGS84) to ITM
+  itm.ToLL()            : converts from ITM to LL (WGS84)
+
+Gauss-Krüger (DHDN, 3° or 6° meridian strips, Rechtswert with zone number prefix):
+  ll.ToGaussKrueger()     : converts from LL (WGS84) to Gauss-Krüger (zone selected by longitude)
+  ll.ToGaussKruegerZone() : converts from LL (WGS84) to Gauss-Krüger in a caller-specified zone
+  gk.ToLL()               : converts from Gauss-Krüger to LL (WGS84)
+  gk.ToLLDatum()          : converts from Gauss-Krüger to LL (given datum, e.g. DHDN)
+  gk.ToUTM()              : converts from Gauss-Krüger to UTM
+  gk.ToMGRS()             : converts from Gauss-Krüger to MGRS
+  gk.Zone()               : returns the zone number (prefix of the Rechtswert)
+  ParseGaussKrueger()     : parses Rechtswert and Hochwert ("3477777 5767789", "R 3477777 H 5767789")
+
+Swiss coordinates (CH1903, Bessel 1841, oblique Mercator, LV95 "2600000 1200000" or LV03 "600000 200000"):
+  ll.ToCH1903()      : converts from LL (WGS84) to CH1903 (LV95 or LV03)
+  ll.ToCH1903Datum() : converts from LL (given datum, e.g. CH1903Datum) to CH1903
+  ch.ToLL()          : converts from CH1903 to LL (WGS84)
+  ch.ToLLDatum()     : converts from CH1903 to LL (given datum)
+  ch.ToMGRS()        : converts from CH1903 to MGRS
+  ch.ToFrame()       : converts between LV03 and LV95 (false easting/northing only)
+  mgrs.ToCH1903()    : converts from MGRS to CH1903
+
+RD New (Amersfoort, Bessel 1841, oblique stereographic, EPSG:28992):
+  ll.ToRD()       : converts from LL (WGS84) to RD (rigorous oblique stereographic projection)
+  ll.ToRDMethod() : converts from LL (WGS84) to RD (RDStereographic or RDPolynomial)
+  rd.ToLL()       : converts from RD to LL (WGS84)
+  rd.ToLLMethod() : converts from RD to LL (RDStereographic or RDPolynomial)
+
+Geohash (base32 cells, precision 1 to 12 characters, e.g. "u4pruydqqvj"):
+  ll.ToGeohash()       : converts from LL to Geohash with given precision
+  geohash.ToLL()       : converts from Geohash to LL (center of the cell)
+  geohash.Bounds()     : returns the bounding box of the Geohash cell
+  geohash.Validate()   : validates the Geohash string
+  geohash.Adjacent()   : returns the adjacent cell in the given direction (N, NE, E, SE, S, SW, W, NW)
+  geohash.Neighbours() : returns the adjacent cells in all directions
+  geohash.ToMGRS()     : converts from Geohash to MGRS (center of the cell)
+  mgrs.ToGeohash()     : converts from MGRS to Geohash
+
+Open Location Code (Plus Codes, full "9F4MGC7R+2F" or short "GC7R+2F"):
+  ll.ToPlusCode()     : converts from LL to a full Plus Code with given code length
+  pluscode.ToLL()     : converts from a full Plus Code to LL (center of the code area)
+  pluscode.Bounds()   : returns the code area (bounding box) of a full Plus Code
+  pluscode.Validate() : validates the Plus Code syntax
+  pluscode.IsFull()   : reports whether the Plus Code is a valid full code
+  pluscode.IsShort()  : reports whether the Plus Code is a valid short code
+  pluscode.Length()   : returns the code length (number of digits)
+  pluscode.Shorten()  : shortens a full Plus Code relative to a reference position
+  pluscode.Recover()  : recovers a full Plus Code from a short code and a reference position
+  pluscode.ToMGRS()   : converts from a full Plus Code to MGRS
+  pluscode.ToUTM()    : converts from a full Plus Code to UTM
+  mgrs.ToPlusCode()   : converts from MGRS to a full Plus Code
+  utm.ToPlusCode()    : converts from UTM to a full Plus Code
+
+Maidenhead locator (QTH locator, 2 to 10 characters, e.g. "JO31le"):
+  ll.ToMaidenhead()       : converts from LL to Maidenhead locator with given length
+  maidenhead.ToLL()       : converts from Maidenhead locator to LL (center of the cell)
+  maidenhead.Bounds()     : returns the bounding box of the locator cell
+  maidenhead.Validate()   : validates the Maidenhead locator
+  maidenhead.Normalize()  : returns the canonical form of the locator (e.g. "JO31le")
+  maidenhead.DistanceTo() : returns the great circle distance between two locators
+  maidenhead.BearingTo()  : returns the initial great circle bearing between two locators
+  maidenhead.ToMGRS()     : converts from Maidenhead locator to MGRS (center of the cell)
+  mgrs.ToMaidenhead()     : converts from MGRS to Maidenhead locator
+
+GARS (Global Area Reference System, 30' cells "006AG", 15' quadrants "006AG3", 5' keypads "006AG39"):
+  ll.ToGARS()     : converts from LL to GARS with given level (GARS30Min, GARS15Min, GARS5Min)
+  gars.ToLL()     : converts from GARS to LL (center of the cell)
+  gars.Bounds()   : returns the bounding box of the GARS cell
+  gars.Polygon()  : returns the corners of the GARS cell as closed ring
+  gars.Validate() : validates the GARS string
+  gars.Level()    : returns the cell size of the GARS cell
+  gars.ToMGRS()   : converts from GARS to MGRS (center of the cell)
+  mgrs.ToGARS()   : converts from MGRS to GARS
+
+GEOREF (World Geographic Reference System, 15° tiles to 0.01 minutes, e.g. "GJPJ3217"):
+  ll.ToGEOREF()      : converts from LL to GEOREF with given precision (2, 4, 8, 10 or 12 characters)
+  ParseGEOREF()      : parses a GEOREF string (e.g. "gj pj 32 17") into canonical form
+  georef.ToLL()      : converts from GEOREF to LL (center of the cell)
+  georef.Bounds()    : returns the bounding box of the GEOREF cell
+  georef.Validate()  : validates the GEOREF string
+  georef.Precision() : returns the precision of the GEOREF string
+  georef.Format()    : formats GEOREF grouped by tile, square and minutes (e.g. "GJ PJ 32 17")
+  georef.ToMGRS()    : converts from GEOREF to MGRS (center of the cell)
+  mgrs.ToGEOREF()    : converts from MGRS to GEOREF
+
+Web Mercator and slippy map tiles (EPSG:3857, z/x/y, quadkey, MGRS overlay):
+  ll.ToWebMercator()     : converts from LL to Web Mercator (EPSG:3857)
+  webmercator.ToLL()     : converts from Web Mercator to LL
+  ll.ToTile()            : returns the slippy map tile (z/x/y) containing the position
+  tile.Bounds()          : returns the bounding box of the tile
+  tile.Validate()        : validates zoom level, column and row of the tile
+  tile.Quadkey()         : converts from tile to quadkey
+  quadkey.ToTile()       : converts from quadkey to tile
+  tile.MGRSGridZones()   : returns the MGRS grid zones overlapping the tile
+  tile.MGRS100kSquares() : returns the MGRS 100k squares overlapping the tile
+
+Geodesic (inverse and direct problem, Karney, distance, azimuths and destination on the ellipsoid):
+  ll.DistanceTo()           : returns distance, forward and back azimuth between two positions (WGS84)
+  ll.DistanceToEllipsoid()  : returns distance, forward and back azimuth on the given ellipsoid
+  utm.DistanceTo()          : returns distance, forward and back azimuth between two UTM coordinates
+  mgrs.DistanceTo()         : returns distance, forward and back azimuth between two MGRS coordinates
+  ll.Destination()          : returns destination and final azimuth from azimuth and distance (WGS84)
+  ll.DestinationEllipsoid() : returns destination and final azimuth on the given ellipsoid
+  utm.Destination()         : returns destination in UTM (zone of the destination)
+  mgrs.Destination()        : returns destination in MGRS (zone of the destination, same accuracy)
+  MilsToDegrees()           : converts NATO mils (6400 per circle) to degrees
+  DegreesToMils()           : converts degrees to NATO mils
+
+Rhumb line (loxodrome, constant bearing on the ellipsoid):
+  ll.RhumbTo()          : returns distance and constant bearing of the rhumb line between two positions
+  ll.RhumbDestination() : returns destination from start point, constant bearing and distance
+  ll.RhumbMidpoint()    : returns the point halfway along the rhumb line between two positions
+
+Grid convergence and point scale factor (UTM, Krüger series):
+  ll.ToUTMFactors() : converts from LL to UTM and returns convergence and scale factor
+  utm.Factors()     : returns convergence and scale factor (zone of the coordinate)
+  mgrs.Factors()    : returns UTM, convergence and scale factor (south-west corner of the MGRS cell)
+
+MGRS cell geometry (cells clipped at zone and band boundaries):
+  mgrs.Center()  : returns the center of the cell (centroid of the clipped cell)
+  mgrs.Corners() : returns the four grid corners of the cell (not clipped)
+  mgrs.Polygon() : returns the cell clipped at the grid zone boundaries (closed ring)
+
+Formatting and rounding (UTM keeps full float precision):
+  utm.Format()         : formats UTM with given decimals and rounding (RoundHalfUp, Truncate)
+  utm.ToMGRSRounding() : converts from UTM to MGRS with given rounding (ToMGRS truncates)
+  ups.Format()         : formats UPS with given decimals and rounding
+  ups.ToMGRSRounding() : converts from UPS to MGRS with given rounding
 
 Data objects:
-  UTM  : ZoneNumber ZoneLetter Easting Northing
-  LL   : Latitude Longitude
-  MGRS : String
+  UTM          : ZoneNumber ZoneLetter Easting Northing
+  LL           : Latitude Longitude
+  UPS          : ZoneLetter Easting Northing
+  MGRS         : String
+  Ellipsoid    : Name A InvF (WGS84, GRS80, Bessel1841, Hayford1924, Clarke1866, Clarke1880, Krassowsky1940, Airy1830, AiryModified)
+  TMEngine     : Krueger (default, Krüger n-series 6th order) or Snyder (USGS series)
+  Datum        : Name Ellipsoid ToWGS84 (WGS84, ETRS89, DHDN, OSGB36, ED50, NAD27, Pulkovo1942, TM65, CH1903Datum, Amersfoort)
+  Helmert      : Tx Ty Tz Rx Ry Rz S Convention (PositionVector, CoordinateFrame) Px Py Pz
+  ECEF         : X Y Z
+  OSGB         : Easting Northing
+  IrishGrid    : Easting Northing
+  ITM          : Easting Northing
+  GaussKrueger : Strip (Strip3, Strip6) Rechtswert Hochwert
+  CH1903       : Frame (LV95, LV03) Easting Northing
+  RD           : X Y
+  RDMethod     : RDStereographic (default, rigorous) or RDPolynomial (approximation polynomials)
+  Geohash      : String
+  PlusCode     : String
+  Maidenhead   : String
+  BoundingBox  : South West North East
+  Direction    : North NorthEast East SouthEast South SouthWest West NorthWest
+  GARS         : String
+  GARSLevel    : GARS30Min GARS15Min GARS5Min
+  GEOREF       : String (GEOREFPrecision: GEOREFTile, GEOREFDegree, GEOREFMinute, GEOREFTenthMinute, GEOREFHundredthMinute)
+  WebMercator  : X Y
+  Tile         : Z X Y
+  Quadkey      : String
+  Geodesic     : Distance ForwardAzimuth BackAzimuth
+  Rhumb        : Distance Bearing
+  UTMFactors   : UTM Convergence Scale
 
 Abbreviations:
+  CH1903 : Swiss coordinate system 1903 (CH1903+ with LV95)
+  DHDN   : Deutsches Hauptdreiecksnetz (Potsdam datum)
+  ECEF   : Earth-Centered, Earth-Fixed (geocentric cartesian coordinate)
+  GARS   : Global Area Reference System
+  GEOREF : World Geographic Reference System
+  GK     : Gauss-Krüger
+  ITM    : Irish Transverse Mercator
   Lat    : Latitude
   Lon    : Longitude
+  LV95   : Landesvermessung 1995 (Swiss reference frame, LV03 = 1903)
   MGRS   : Military Grid Reference System (same as UTMREF)
+  OLC    : Open Location Code (Plus Codes)
+  OSGB   : Ordnance Survey Great Britain (British National Grid)
+  QTH    : Maidenhead locator (amateur radio)
+  RD     : Rijksdriehoeksmeting (Dutch national grid)
+  UPS    : Universal Polar Stereographic
   UTM    : Universal Transverse Mercator
   UTMREF : UTM Reference System (same as MGRS)
   WGS84  : World Geodetic System 1984 (same as EPSG:4326)
@@ -81,9 +303,6 @@ package coco
 import (
 	"fmt"
 	"math"
-	"regexp"
-	"strconv"
-	"strings"
 )
 
 // UTM defines coordinate in Universal Transverse Mercator
@@ -95,11 +314,79 @@ type UTM struct {
 }
 
 /*
-String returns stringified UTM object.
+String returns stringified UTM object (rounded to full meters).
 */
 func (utm UTM) String() string {
 
-	return fmt.Sprintf("%d%c %.0f %.0f", utm.ZoneNumber, utm.ZoneLetter, utm.Easting, utm.Northing)
+	return utm.Format(0, RoundHalfUp)
+}
+
+/*
+Format returns stringified UTM object with the given number of decimals.
+decimals holds the number of decimals for easting and northing (0 = meters, 2 = centimeters, ...).
+rounding holds the rounding mode (RoundHalfUp or Truncate).
+*/
+func (utm UTM) Format(decimals int, rounding Rounding) string {
+
+	if decimals < 0 {
+		decimals = 0
+	}
+	unit := math.Pow(10, -float64(decimals))
+
+	return fmt.Sprintf("%d%c %.*f %.*f", utm.ZoneNumber, utm.ZoneLetter,
+		decimals, roundValue(utm.Easting, unit, rounding),
+		decimals, roundValue(utm.Northing, unit, rounding))
+}
+
+// Rounding defines how easting and northing values are reduced to the wanted precision
+type Rounding int
+
+// rounding modes
+const (
+	RoundHalfUp Rounding = iota // round to nearest, halves away from the lower grid line
+	Truncate                    // cut off to the lower grid line (MGRS convention)
+)
+
+/*
+roundValue rounds or truncates a (positive) grid value to a multiple of unit.
+value holds the easting or northing in meters.
+unit holds the wanted precision in meters (e.g. 0.01, 1, 1000).
+rounding holds the rounding mode.
+*/
+func roundValue(value, unit float64, rounding Rounding) float64 {
+
+	// values within a few ulps of a grid line are snapped to it, this guards against binary
+	// representation errors (e.g. 0.29 / 0.01 = 28.999999999999996) without a fixed bias
+	scaled := value / unit
+	if nearest := math.Round(scaled); math.Abs(scaled-nearest) <= 1e-14*math.Max(1, math.Abs(scaled)) {
+		scaled = nearest
+	}
+	if rounding == Truncate {
+		return math.Floor(scaled) * unit
+	}
+
+	return math.Floor(scaled+0.5) * unit
+}
+
+// Hemisphere defines the northern or southern hemisphere
+type Hemisphere byte
+
+// hemispheres
+const (
+	NorthernHemisphere Hemisphere = 'N'
+	SouthernHemisphere Hemisphere = 'S'
+)
+
+/*
+Hemisphere returns the hemisphere of the UTM coordinate (derived from the latitude band letter).
+*/
+func (utm UTM) Hemisphere() Hemisphere {
+
+	if utm.ZoneLetter < 'N' {
+		return SouthernHemisphere
+	}
+
+	return NorthernHemisphere
 }
 
 // LL defines coordinate in Longitude / Latitude
@@ -136,40 +423,75 @@ const (
 )
 
 /*
-ToMGRS converts Lon Lat to MGRS.
+ToMGRS converts Lon Lat to MGRS (WGS84 ellipsoid).
 accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
 */
 func (ll LL) ToMGRS(accuracy int) (MGRS, error) {
 
+	return ll.ToMGRSEllipsoid(accuracy, EllipsoidWGS84)
+}
+
+/*
+ToMGRSEllipsoid converts Lon Lat to MGRS based on the given ellipsoid.
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+ellipsoid holds the reference ellipsoid of the Lon Lat coordinate.
+*/
+func (ll LL) ToMGRSEllipsoid(accuracy int, ellipsoid Ellipsoid) (MGRS, error) {
+
 	if ll.Lon < -180 || ll.Lon > 180 {
 		return "", fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
 	}
 	if ll.Lat < -90 || ll.Lat > 90 {
 		return "", fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
 	}
-	if ll.Lat < -80 || ll.Lat > 84 {
-		return "", fmt.Errorf("polar regions below 80°S and above 84°N not supported, lat = %v", ll.Lat)
+	if isPolarLat(ll.Lat) {
+		// polar regions below 80°S and above 84°N are covered by UPS
+		ups := ll.ToUPSEllipsoid(ellipsoid)
+		return ups.ToMGRS(accuracy)
 	}
 
-	utm := ll.ToUTM()
+	utm := ll.ToUTMEllipsoid(ellipsoid)
 	mgrs := utm.ToMGRS(accuracy)
 
 	return mgrs, nil
 }
 
 /*
-ToLL converts MGRS/UTMREF to Lon Lat.
+ToLL converts MGRS/UTMREF to Lon Lat (WGS84 ellipsoid).
 */
 func (mgrs MGRS) ToLL() (LL, int, error) {
 
+	return mgrs.ToLLEllipsoid(EllipsoidWGS84)
+}
+
+/*
+ToLLEllipsoid converts MGRS/UTMREF to Lon Lat based on the given ellipsoid.
+ellipsoid holds the reference ellipsoid of the MGRS coordinate.
+*/
+func (mgrs MGRS) ToLLEllipsoid(ellipsoid Ellipsoid) (LL, int, error) {
+
+	if mgrs.isPolar() {
+		ups, accuracy, err := mgrs.ToUPS()
+		if err != nil {
+			return LL{}, 0, fmt.Errorf("error <%w> at mgrs.ToUPS()", err)
+		}
+
+		ll, err := ups.ToLLEllipsoid(ellipsoid)
+		if err != nil {
+			return LL{}, 0, fmt.Errorf("error <%v> at ups.ToLLEllipsoid(), ups = %#v", err, ups)
+		}
+
+		return ll, accuracy, nil
+	}
+
 	utm, accuracy, err := mgrs.ToUTM()
 	if err != nil {
-		return LL{}, 0, fmt.Errorf("error <%v> at mgrs.ToUTM()", err)
+		return LL{}, 0, fmt.Errorf("error <%w> at mgrs.ToUTM()", err)
 	}
 
-	ll, err := utm.ToLL()
+	ll, err := utm.ToLLEllipsoid(ellipsoid)
 	if err != nil {
-		return LL{}, 0, fmt.Errorf("error <%v> at utm.ToLL(), utm = %#v", err, utm)
+		return LL{}, 0, fmt.Errorf("error <%v> at utm.ToLLEllipsoid(), utm = %#v", err, utm)
 	}
 
 	return ll, accuracy, nil
@@ -194,17 +516,147 @@ func radToDeg(rad float64) float64 {
 }
 
 /*
-ToUTM converts Lon Lat to UTM.
+ToUTM converts Lon Lat to UTM (WGS84 ellipsoid, Krüger series).
 */
 func (ll LL) ToUTM() UTM {
 
+	return ll.ToUTMEllipsoid(EllipsoidWGS84)
+}
+
+/*
+ToUTMEllipsoid converts Lon Lat to UTM based on the given ellipsoid.
+ellipsoid holds the reference ellipsoid of the Lon Lat coordinate.
+*/
+func (ll LL) ToUTMEllipsoid(ellipsoid Ellipsoid) UTM {
+
+	return ll.ToUTMEngine(ellipsoid, Krueger)
+}
+
+/*
+ToUTMEngine converts Lon Lat to UTM based on the given ellipsoid and transverse Mercator engine.
+ellipsoid holds the reference ellipsoid of the Lon Lat coordinate.
+engine holds the transverse Mercator series (Krueger or Snyder).
+*/
+func (ll LL) ToUTMEngine(ellipsoid Ellipsoid, engine TMEngine) UTM {
+
 	Lat := ll.Lat
 	Long := ll.Lon
-	a := 6378137.0           //ellip.radius;
-	eccSquared := 0.00669438 //ellip.eccsq;
-	k0 := 0.9996
-	LatRad := degToRad(Lat)
-	LongRad := degToRad(Long)
+
+	ZoneNumber := getZoneNumber(Lat, Long)
+
+	tm := utmProjection(ellipsoid, engine, ZoneNumber)
+	if Lat < 0.0 {
+		tm.falseNorthing = 10000000.0 // 10000000 meters offset for southern hemisphere
+	}
+	UTMEasting, UTMNorthing := tm.forward(Lat, Long)
+
+	utm := UTM{}
+	utm.ZoneNumber = ZoneNumber
+	utm.ZoneLetter = getLetterDesignator(Lat)
+	utm.Easting = UTMEasting
+	utm.Northing = UTMNorthing
+
+	return utm
+}
+
+/*
+ToUTMZone converts Lon Lat to UTM in the given zone (WGS84 ellipsoid).
+zone holds the wanted zone number (1-60), e.g. 32 for all of Germany.
+hemisphere holds the hemisphere of the coordinate (NorthernHemisphere or SouthernHemisphere).
+*/
+func (ll LL) ToUTMZone(zone int, hemisphere Hemisphere) (UTM, error) {
+
+	return ll.ToUTMZoneEllipsoid(zone, hemisphere, EllipsoidWGS84)
+}
+
+/*
+ToUTMZoneEllipsoid converts Lon Lat to UTM in the given zone based on the given ellipsoid.
+The zone is not selected automatically (no Norway and Svalbard exceptions), points outside
+of the zone are projected into the extended zone (Krüger series).
+zone holds the wanted zone number (1-60).
+hemisphere holds the hemisphere of the coordinate (NorthernHemisphere or SouthernHemisphere).
+ellipsoid holds the reference ellipsoid of the Lon Lat coordinate.
+*/
+func (ll LL) ToUTMZoneEllipsoid(zone int, hemisphere Hemisphere, ellipsoid Ellipsoid) (UTM, error) {
+
+	if zone < 1 || zone > 60 {
+		return UTM{}, fmt.Errorf("invalid zone number, zone number = %v", zone)
+	}
+	if ll.Lon < -180 || ll.Lon > 180 {
+		return UTM{}, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if ll.Lat < -80 || ll.Lat > 84 {
+		return UTM{}, fmt.Errorf("latitude outside of utm range (80°S to 84°N), lat = %v", ll.Lat)
+	}
+
+	zoneLetter := getLetterDesignator(ll.Lat)
+	switch hemisphere {
+	case NorthernHemisphere:
+		if ll.Lat < 0 {
+			return UTM{}, fmt.Errorf("latitude not in northern hemisphere, lat = %v", ll.Lat)
+		}
+	case SouthernHemisphere:
+		if ll.Lat > 0 {
+			return UTM{}, fmt.Errorf("latitude not in southern hemisphere, lat = %v", ll.Lat)
+		}
+		// the equator belongs to band N, in southern hemisphere notation it is the upper border of band M
+		if ll.Lat == 0 {
+			zoneLetter = 'M'
+		}
+	default:
+		return UTM{}, fmt.Errorf("invalid hemisphere, hemisphere = %c", hemisphere)
+	}
+
+	tm := utmProjection(ellipsoid, Krueger, zone)
+	if hemisphere == SouthernHemisphere {
+		tm.falseNorthing = 10000000.0 // 10000000 meters offset for southern hemisphere
+	}
+
+	utm := UTM{}
+	utm.ZoneNumber = zone
+	utm.ZoneLetter = zoneLetter
+	utm.Easting, utm.Northing = tm.forward(ll.Lat, ll.Lon)
+
+	return utm, nil
+}
+
+/*
+ToZone re-projects UTM into another (e.g. neighbouring) zone (WGS84 ellipsoid).
+zone holds the wanted zone number (1-60).
+*/
+func (utm UTM) ToZone(zone int) (UTM, error) {
+
+	return utm.ToZoneEllipsoid(zone, EllipsoidWGS84)
+}
+
+/*
+ToZoneEllipsoid re-projects UTM into another (e.g. neighbouring) zone based on the given ellipsoid.
+zone holds the wanted zone number (1-60).
+ellipsoid holds the reference ellipsoid of the UTM coordinate.
+*/
+func (utm UTM) ToZoneEllipsoid(zone int, ellipsoid Ellipsoid) (UTM, error) {
+
+	if zone < 1 || zone > 60 {
+		return UTM{}, fmt.Errorf("invalid zone number, zone number = %v", zone)
+	}
+
+	ll, err := utm.ToLLEllipsoid(ellipsoid)
+	if err != nil {
+		return UTM{}, fmt.Errorf("error <%v> at utm.ToLLEllipsoid(), utm = %#v", err, utm)
+	}
+
+	utmZone, err := ll.ToUTMZoneEllipsoid(zone, utm.Hemisphere(), ellipsoid)
+	if err != nil {
+		return UTM{}, fmt.Errorf("error <%v> at ll.ToUTMZoneEllipsoid(), ll = %#v", err, ll)
+	}
+
+	return utmZone, nil
+}
+
+/*
+getZoneNumber calculates the UTM zone number for the given latitude and longitude (including the Norway and Svalbard exceptions).
+*/
+func getZoneNumber(Lat, Long float64) int {
 
 	ZoneNumber := 0 // (int)
 	ZoneNumber = int(math.Floor((Long+180)/6) + 1)
@@ -219,8 +671,8 @@ func (ll LL) ToUTM() UTM {
 		ZoneNumber = 32
 	}
 
-	// special zones for Svalbard
-	if Lat >= 72.0 && Lat < 84.0 {
+	// special zones for Svalbard (band X includes 84°N)
+	if Lat >= 72.0 && Lat <= 84.0 {
 		if Long >= 0.0 && Long < 9.0 {
 			ZoneNumber = 31
 		} else if Long >= 9.0 && Long < 21.0 {
@@ -232,90 +684,68 @@ func (ll LL) ToUTM() UTM {
 		}
 	}
 
-	LongOrigin := (ZoneNumber-1)*6 - 180 + 3 // +3 puts origin in middle of zone
-	LongOriginRad := degToRad(float64(LongOrigin))
+	return ZoneNumber
+}
 
-	eccPrimeSquared := eccSquared / (1 - eccSquared)
+/*
+utmProjection returns the transverse Mercator parameters of the given UTM zone (northern hemisphere).
+*/
+func utmProjection(ellipsoid Ellipsoid, engine TMEngine, zoneNumber int) transverseMercator {
 
-	N := a / math.Sqrt(1-eccSquared*math.Sin(LatRad)*math.Sin(LatRad))
-	T := math.Tan(LatRad) * math.Tan(LatRad)
-	C := eccPrimeSquared * math.Cos(LatRad) * math.Cos(LatRad)
-	A := math.Cos(LatRad) * (LongRad - LongOriginRad)
+	// there are 60 zones with zone 1 being at West -180 to -174
+	LongOrigin := (zoneNumber-1)*6 - 180 + 3 // +3 puts origin in middle of zone
 
-	M := a * ((1-eccSquared/4-3*eccSquared*eccSquared/64-5*eccSquared*eccSquared*eccSquared/256)*LatRad - (3*eccSquared/8+3*eccSquared*eccSquared/32+45*eccSquared*eccSquared*eccSquared/1024)*math.Sin(2*LatRad) + (15*eccSquared*eccSquared/256+45*eccSquared*eccSquared*eccSquared/1024)*math.Sin(4*LatRad) - (35*eccSquared*eccSquared*eccSquared/3072)*math.Sin(6*LatRad))
+	return transverseMercator{
+		ellipsoid:    ellipsoid,
+		engine:       engine,
+		lon0:         float64(LongOrigin),
+		k0:           0.9996,
+		falseEasting: 500000.0,
+	}
+}
 
-	UTMEasting := (k0*N*(A+(1-T+C)*A*A*A/6.0+(5-18*T+T*T+72*C-58*eccPrimeSquared)*A*A*A*A*A/120.0) + 500000.0)
+/*
+ToLL converts UTM to Lon Lat (WGS84 ellipsoid).
+*/
+func (utm UTM) ToLL() (LL, error) {
 
-	UTMNorthing := (k0 * (M + N*math.Tan(LatRad)*(A*A/2+(5-T+9*C+4*C*C)*A*A*A*A/24.0+(61-58*T+T*T+600*C-330*eccPrimeSquared)*A*A*A*A*A*A/720.0)))
-	if Lat < 0.0 {
-		UTMNorthing += 10000000.0 // 10000000 meters offset for southern hemisphere
-	}
+	return utm.ToLLEllipsoid(EllipsoidWGS84)
+}
 
-	utm := UTM{}
-	utm.ZoneNumber = ZoneNumber
-	utm.ZoneLetter = getLetterDesignator(Lat)
-	utm.Easting = math.Trunc(UTMEasting)
-	utm.Northing = math.Trunc(UTMNorthing)
+/*
+ToLLEllipsoid converts UTM to Lon Lat based on the given ellipsoid.
+ellipsoid holds the reference ellipsoid of the UTM coordinate.
+*/
+func (utm UTM) ToLLEllipsoid(ellipsoid Ellipsoid) (LL, error) {
 
-	return utm
+	return utm.ToLLEngine(ellipsoid, Krueger)
 }
 
 /*
-ToLL converts UTM to Lon Lat.
+ToLLEngine converts UTM to Lon Lat based on the given ellipsoid and transverse Mercator engine.
+ellipsoid holds the reference ellipsoid of the UTM coordinate.
+engine holds the transverse Mercator series (Krueger or Snyder).
 */
-func (utm UTM) ToLL() (LL, error) {
+func (utm UTM) ToLLEngine(ellipsoid Ellipsoid, engine TMEngine) (LL, error) {
 
 	zoneNumber := utm.ZoneNumber
 	zoneLetter := utm.ZoneLetter
-	UTMEasting := utm.Easting
-	UTMNorthing := utm.Northing
 
 	// check the ZoneNummber is valid
 	if zoneNumber < 0 || zoneNumber > 60 {
 		return LL{}, fmt.Errorf("invalid zone number, zone number = %v", zoneNumber)
 	}
 
-	k0 := 0.9996
-	a := 6378137.0           //ellip.radius;
-	eccSquared := 0.00669438 //ellip.eccsq;
-	e1 := (1 - math.Sqrt(1-eccSquared)) / (1 + math.Sqrt(1-eccSquared))
-
-	// remove 500,000 meters offset for longitude
-	x := UTMEasting - 500000.0
-	y := UTMNorthing
+	tm := utmProjection(ellipsoid, engine, zoneNumber)
 
 	// We must know somehow if we are in the Northern or Southern hemisphere, this is the only time we use the letter.
 	// So even if the Zone letter isn't exactly correct it should indicate the hemisphere correctly.
 	if zoneLetter < 'N' {
-		y -= 10000000.0 // remove 10,000,000 meters offset used
-		// for southern hemisphere
+		tm.falseNorthing = 10000000.0 // remove 10,000,000 meters offset used for southern hemisphere
 	}
 
-	// there are 60 zones with zone 1 being at West -180 to -174
-	LongOrigin := (zoneNumber-1)*6 - 180 + 3 // +3 puts origin in middle of zone
-
-	eccPrimeSquared := (eccSquared) / (1 - eccSquared)
-
-	M := y / k0
-	mu := M / (a * (1 - eccSquared/4 - 3*eccSquared*eccSquared/64 - 5*eccSquared*eccSquared*eccSquared/256))
-
-	phi1Rad := mu + (3*e1/2-27*e1*e1*e1/32)*math.Sin(2*mu) + (21*e1*e1/16-55*e1*e1*e1*e1/32)*math.Sin(4*mu) + (151*e1*e1*e1/96)*math.Sin(6*mu)
-
-	N1 := a / math.Sqrt(1-eccSquared*math.Sin(phi1Rad)*math.Sin(phi1Rad))
-	T1 := math.Tan(phi1Rad) * math.Tan(phi1Rad)
-	C1 := eccPrimeSquared * math.Cos(phi1Rad) * math.Cos(phi1Rad)
-	R1 := a * (1 - eccSquared) / math.Pow(1-eccSquared*math.Sin(phi1Rad)*math.Sin(phi1Rad), 1.5)
-	D := x / (N1 * k0)
-
-	lat := phi1Rad - (N1*math.Tan(phi1Rad)/R1)*(D*D/2-(5+3*T1+10*C1-4*C1*C1-9*eccPrimeSquared)*D*D*D*D/24+(61+90*T1+298*C1+45*T1*T1-252*eccPrimeSquared-3*C1*C1)*D*D*D*D*D*D/720)
-	lat = radToDeg(lat)
-
-	lon := (D - (1+2*T1+C1)*D*D*D/6 + (5-2*C1+28*T1-3*C1*C1+8*eccPrimeSquared+24*T1*T1)*D*D*D*D*D/120) / math.Cos(phi1Rad)
-	lon = float64(LongOrigin) + radToDeg(lon)
-
 	ll := LL{}
-	ll.Lat = lat
-	ll.Lon = lon
+	ll.Lat, ll.Lon = tm.inverse(utm.Easting, utm.Northing)
 
 	return ll, nil
 }
@@ -377,37 +807,69 @@ func getLetterDesignator(lat float64) byte {
 /*
 ToMGRS converts UTM to MGRS/UTMREF.
 accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+Easting and northing are truncated to the accuracy (MGRS convention).
 */
 func (utm UTM) ToMGRS(accuracy int) MGRS {
 
+	return utm.ToMGRSRounding(accuracy, Truncate)
+}
+
+/*
+ToMGRSRounding converts UTM to MGRS/UTMREF with the given rounding mode.
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+rounding holds the rounding mode (Truncate or RoundHalfUp).
+*/
+func (utm UTM) ToMGRSRounding(accuracy int, rounding Rounding) MGRS {
+
+	digits := accuracyToDigits(accuracy)
+	unit := math.Pow(10, float64(5-digits))
+	easting := roundValue(utm.Easting, unit, rounding)
+	northing := roundValue(utm.Northing, unit, rounding)
+
+	mgrs := fmt.Sprintf("%d%s%s%s%s",
+		utm.ZoneNumber,
+		string(utm.ZoneLetter),
+		get100kID(easting, northing, utm.ZoneNumber),
+		mgrsDigits(easting, digits),
+		mgrsDigits(northing, digits))
+
+	return MGRS(mgrs)
+}
+
+/*
+accuracyToDigits converts the MGRS accuracy in meters to the number of digits per easting/northing.
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+*/
+func accuracyToDigits(accuracy int) int {
+
 	// meters to number of digits
 	switch accuracy {
 	case 1:
-		accuracy = 5
+		return 5
 	case 10:
-		accuracy = 4
+		return 4
 	case 100:
-		accuracy = 3
+		return 3
 	case 1000:
-		accuracy = 2
+		return 2
 	case 10000:
-		accuracy = 1
-	default:
-		accuracy = 5
+		return 1
 	}
 
-	// prepend with leading zeroes
-	seasting := "00000" + fmt.Sprintf("%.0f", utm.Easting)
-	snorthing := "00000" + fmt.Sprintf("%.0f", utm.Northing)
+	return 5
+}
 
-	mgrs := fmt.Sprintf("%d%s%s%s%s",
-		utm.ZoneNumber,
-		string(utm.ZoneLetter),
-		get100kID(utm.Easting, utm.Northing, utm.ZoneNumber),
-		seasting[len(seasting)-5:len(seasting)-5+accuracy],
-		snorthing[len(snorthing)-5:len(snorthing)-5+accuracy])
+/*
+mgrsDigits returns the leading digits of the position within the 100k square.
+value holds the easting or northing in meters (already reduced to the wanted accuracy).
+digits holds the number of wanted digits (1-5).
+*/
+func mgrsDigits(value float64, digits int) string {
 
-	return MGRS(mgrs)
+	// prepend with leading zeroes
+	svalue := "00000" + fmt.Sprintf("%.0f", math.Floor(value+1e-7))
+
+	return svalue[len(svalue)-5 : len(svalue)-5+digits]
 }
 
 /*
@@ -498,65 +960,39 @@ func getLetter100kID(column, row, parm int) string {
 		rowInt = rowInt - charV + charA - 1
 	}
 
-	twoLetter := string(colInt) + string(rowInt)
+	twoLetter := string(rune(colInt)) + string(rune(rowInt))
 	return twoLetter
 }
 
 /*
 ToUTM converts MGRS/UTMREF to UTM.
+The 100k square must exist in the grid zone, otherwise an error wrapping ErrSquareNotInZone is returned.
 */
 func (mgrs MGRS) ToUTM() (UTM, int, error) {
 
-	mgrsTmp := string(mgrs)
 	if mgrs == "" {
 		return UTM{}, 0, fmt.Errorf("invalid empty mgrs string")
 	}
 
-	mgrsTmp = strings.ToUpper(mgrsTmp)
-
-	sb := ""
-	i := 0
-
-	// get Zone number
-	re := regexp.MustCompile("[A-Z]")
-	for !re.MatchString(string(mgrsTmp[i])) {
-		if i >= 2 {
-			return UTM{}, 0, fmt.Errorf("bad conversion, mgrs = %s", mgrs)
-		}
-		sb += string(mgrsTmp[i])
-		i++
-	}
-
-	zoneNumberTmp, err := strconv.ParseInt(sb, 10, 0)
+	c, err := ParseMGRS(string(mgrs))
 	if err != nil {
-		return UTM{}, 0, fmt.Errorf("error <%v> at strconv.ParseInt(), string = %v", err, sb)
+		return UTM{}, 0, err
 	}
-	zoneNumber := int(zoneNumberTmp)
 
-	// A good MGRS string has to be 4-5 digits long, ##AAA/#AAA at least.
-	if i == 0 || i+3 > len(mgrsTmp) {
-		return UTM{}, 0, fmt.Errorf("bad conversion, mgrs = %s", mgrs)
+	if c.ZoneNumber == 0 {
+		return UTM{}, 0, fmt.Errorf("polar mgrs not covered by utm (use mgrs.ToUPS()), mgrs = %s", mgrs)
 	}
 
-	zoneLetter := mgrsTmp[i]
-	i++
-
-	// Should we check the zone letter here? Why not.
-	if zoneLetter <= 'A' || zoneLetter == 'B' || zoneLetter == 'Y' || zoneLetter >= 'Z' || zoneLetter == 'I' || zoneLetter == 'O' {
-		return UTM{}, 0, fmt.Errorf("zone letter %v not handled, mgrs = %s", zoneLetter, mgrs)
-	}
-
-	hunK := mgrsTmp[i : i+2]
-	i += 2
-
+	zoneNumber := c.ZoneNumber
+	zoneLetter := c.ZoneLetter
 	set := get100kSetForZone(zoneNumber)
 
-	east100k, err := getEastingFromChar(hunK[0], set)
+	east100k, err := getEastingFromChar(c.ID100k[0], set)
 	if err != nil {
 		return UTM{}, 0, fmt.Errorf("error <%v> at getEastingFromChar()", err)
 	}
 
-	north100k, err := getNorthingFromChar(hunK[1], set)
+	north100k, err := getNorthingFromChar(c.ID100k[1], set)
 	if err != nil {
 		return UTM{}, 0, fmt.Errorf("error <%v> at getNorthingFromChar()", err)
 	}
@@ -571,46 +1007,24 @@ func (mgrs MGRS) ToUTM() (UTM, int, error) {
 		north100k += 2000000
 	}
 
-	// calculate the char index for easting/northing separator
-	remainder := len(mgrsTmp) - i
-
-	if remainder%2 != 0 {
-		return UTM{}, 0, fmt.Errorf("uneven number of digits, mgrs = %s", mgrs)
-	}
-
-	sep := remainder / 2
-
-	sepEasting := 0.0
-	sepNorthing := 0.0
-	accuracy := 0.0
-	if sep > 0 {
-		accuracy = 100000.0 / math.Pow(10, float64(sep))
-
-		sepEastingString := mgrsTmp[i : i+sep]
-		tmpEasting, err := strconv.ParseFloat(sepEastingString, 64)
-		if err != nil {
-			return UTM{}, 0, fmt.Errorf("error <%v> at strconv.ParseFloat(), easting string = %v", err, sepEastingString)
-		}
-		sepEasting = tmpEasting * accuracy
-
-		sepNorthingString := mgrsTmp[i+sep:]
-		tmpNorthing, _ := strconv.ParseFloat(sepNorthingString, 64)
-		if err != nil {
-			return UTM{}, 0, fmt.Errorf("error <%v> at strconv.ParseFloat(), northing string = %v", err, sepNorthingString)
-		}
-		sepNorthing = tmpNorthing * accuracy
-	}
-
-	easting := sepEasting + east100k
-	northing := sepNorthing + north100k
+	sepEasting, sepNorthing, accuracy := c.values()
 
 	utm := UTM{}
 	utm.ZoneNumber = zoneNumber
 	utm.ZoneLetter = zoneLetter
-	utm.Easting = easting
-	utm.Northing = northing
+	utm.Easting = sepEasting + east100k
+	utm.Northing = sepNorthing + north100k
+
+	// the 100k letters repeat, reject squares (cells) not existing in the grid zone
+	size := float64(accuracy)
+	if accuracy == 0 {
+		size = 100000
+	}
+	if err := checkInZone(utm, size); err != nil {
+		return UTM{}, 0, fmt.Errorf("%w, mgrs = %s", err, mgrs)
+	}
 
-	return utm, int(accuracy), nil
+	return utm, accuracy, nil
 }
 
 /*
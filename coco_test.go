@@ -64,16 +64,16 @@ func TestLL_ToUTM(t *testing.T) {
 		utm UTM // out
 	}{
 		// positive tests
-		{LL{Lat: 51.95, Lon: 7.53}, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973, Northing: 5756497}},
-		{LL{Lat: 52.482728, Lon: -1.908445}, UTM{ZoneNumber: 30, ZoneLetter: 'U', Easting: 574125, Northing: 5815290}},
-		{LL{Lat: -19.887495, Lon: -43.932663}, UTM{ZoneNumber: 23, ZoneLetter: 'K', Easting: 611733, Northing: 7800614}},
-		{LL{Lat: 60.0, Lon: 4.0}, UTM{ZoneNumber: 32, ZoneLetter: 'V', Easting: 221288, Northing: 6661953}},  // Norway 31->32
-		{LL{Lat: 75.0, Lon: 8.0}, UTM{ZoneNumber: 31, ZoneLetter: 'X', Easting: 644293, Northing: 8329692}},  // Svalbard 32->31
-		{LL{Lat: 75.0, Lon: 10.0}, UTM{ZoneNumber: 33, ZoneLetter: 'X', Easting: 355706, Northing: 8329692}}, // Svalbard 32->33
-		{LL{Lat: 75.0, Lon: 10.0}, UTM{ZoneNumber: 33, ZoneLetter: 'X', Easting: 355706, Northing: 8329692}}, // Svalbard 34->33
-		{LL{Lat: 75.0, Lon: 22.0}, UTM{ZoneNumber: 35, ZoneLetter: 'X', Easting: 355706, Northing: 8329692}}, // Svalbard 34->35
-		{LL{Lat: 75.0, Lon: 32.0}, UTM{ZoneNumber: 35, ZoneLetter: 'X', Easting: 644293, Northing: 8329692}}, // Svalbard 36->35
-		{LL{Lat: 75.0, Lon: 34.0}, UTM{ZoneNumber: 37, ZoneLetter: 'X', Easting: 355706, Northing: 8329692}}, // Svalbard 36->37
+		{LL{Lat: 51.95, Lon: 7.53}, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.96, Northing: 5756497.74}},
+		{LL{Lat: 52.482728, Lon: -1.908445}, UTM{ZoneNumber: 30, ZoneLetter: 'U', Easting: 574125.98, Northing: 5815290.89}},
+		{LL{Lat: -19.887495, Lon: -43.932663}, UTM{ZoneNumber: 23, ZoneLetter: 'K', Easting: 611733.14, Northing: 7800614.36}},
+		{LL{Lat: 60.0, Lon: 4.0}, UTM{ZoneNumber: 32, ZoneLetter: 'V', Easting: 221288.77, Northing: 6661953.04}},  // Norway 31->32
+		{LL{Lat: 75.0, Lon: 8.0}, UTM{ZoneNumber: 31, ZoneLetter: 'X', Easting: 644293.43, Northing: 8329692.65}},  // Svalbard 32->31
+		{LL{Lat: 75.0, Lon: 10.0}, UTM{ZoneNumber: 33, ZoneLetter: 'X', Easting: 355706.57, Northing: 8329692.65}}, // Svalbard 32->33
+		{LL{Lat: 75.0, Lon: 10.0}, UTM{ZoneNumber: 33, ZoneLetter: 'X', Easting: 355706.57, Northing: 8329692.65}}, // Svalbard 34->33
+		{LL{Lat: 75.0, Lon: 22.0}, UTM{ZoneNumber: 35, ZoneLetter: 'X', Easting: 355706.57, Northing: 8329692.65}}, // Svalbard 34->35
+		{LL{Lat: 75.0, Lon: 32.0}, UTM{ZoneNumber: 35, ZoneLetter: 'X', Easting: 644293.43, Northing: 8329692.65}}, // Svalbard 36->35
+		{LL{Lat: 75.0, Lon: 34.0}, UTM{ZoneNumber: 37, ZoneLetter: 'X', Easting: 355706.57, Northing: 8329692.65}}, // Svalbard 36->37
 		// negative tests
 		// nothing to do here
 	}
@@ -81,8 +81,70 @@ func TestLL_ToUTM(t *testing.T) {
 	for _, test := range tests {
 		utm := test.ll.ToUTM()
 		function := fmt.Sprintf("ll = %s, ToUTM()", test.ll)
-		got := utm.String()
-		want := test.utm.String()
+		got := utm.Format(2, RoundHalfUp)
+		want := test.utm.Format(2, RoundHalfUp)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_ToUTMZone(t *testing.T) {
+
+	var tests = []struct {
+		ll         LL         // in
+		zone       int        // in
+		hemisphere Hemisphere // in
+		utm        string     // out
+		err        error      // out
+	}{
+		// positive tests
+		{LL{Lat: 52.5186, Lon: 13.4083}, 33, NorthernHemisphere, "33U 391999.72 5819911.51", nil}, // berlin, own zone
+		{LL{Lat: 52.5186, Lon: 13.4083}, 32, NorthernHemisphere, "32U 799046.06 5827857.98", nil}, // berlin, forced into zone 32
+		{LL{Lat: 47.5, Lon: 5.9}, 32, NorthernHemisphere, "32T 266538.19 5265388.38", nil},
+		{LL{Lat: 60.0, Lon: 4.0}, 31, NorthernHemisphere, "31V 555776.27 6651832.74", nil}, // no Norway exception
+		{LL{Lat: -10.0, Lon: -60.5}, 21, SouthernHemisphere, "21L 116189.84 8892549.97", nil},
+		{LL{Lat: 0.0, Lon: 9.0}, 32, SouthernHemisphere, "32M 500000.00 10000000.00", nil},
+		// negative tests
+		{LL{Lat: 52.5186, Lon: 13.4083}, 61, NorthernHemisphere, "0\x00 0.00 0.00", fmt.Errorf("invalid zone number, zone number = 61")},
+		{LL{Lat: 52.5186, Lon: 13.4083}, 33, SouthernHemisphere, "0\x00 0.00 0.00", fmt.Errorf("latitude not in southern hemisphere, lat = 52.5186")},
+		{LL{Lat: -10.0, Lon: -60.5}, 21, NorthernHemisphere, "0\x00 0.00 0.00", fmt.Errorf("latitude not in northern hemisphere, lat = -10")},
+		{LL{Lat: 85.0, Lon: 13.4083}, 33, NorthernHemisphere, "0\x00 0.00 0.00", fmt.Errorf("latitude outside of utm range (80°S to 84°N), lat = 85")},
+		{LL{Lat: 52.5186, Lon: 13.4083}, 33, Hemisphere('X'), "0\x00 0.00 0.00", fmt.Errorf("invalid hemisphere, hemisphere = X")},
+	}
+
+	for _, test := range tests {
+		utm, err := test.ll.ToUTMZone(test.zone, test.hemisphere)
+		function := fmt.Sprintf("ll = %s, ToUTMZone(%d, %c)", test.ll, test.zone, test.hemisphere)
+		got := fmt.Sprintf("%q %v", utm.Format(2, RoundHalfUp), err)
+		want := fmt.Sprintf("%q %v", test.utm, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestUTM_ToZone(t *testing.T) {
+
+	var tests = []struct {
+		utm  UTM    // in
+		zone int    // in
+		out  string // out
+		err  error  // out
+	}{
+		// positive tests
+		{UTM{ZoneNumber: 33, ZoneLetter: 'U', Easting: 391999.7157, Northing: 5819911.5068}, 32, "32U 799046.06 5827857.98", nil},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 799046.0623, Northing: 5827857.9770}, 33, "33U 391999.72 5819911.51", nil},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 799046.0623, Northing: 5827857.9770}, 32, "32U 799046.06 5827857.98", nil},
+		// negative tests
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 799046.0623, Northing: 5827857.9770}, 0, "0\x00 0.00 0.00", fmt.Errorf("invalid zone number, zone number = 0")},
+	}
+
+	for _, test := range tests {
+		utm, err := test.utm.ToZone(test.zone)
+		function := fmt.Sprintf("utm = %s, ToZone(%d)", test.utm, test.zone)
+		got := fmt.Sprintf("%q %v", utm.Format(2, RoundHalfUp), err)
+		want := fmt.Sprintf("%q %v", test.out, test.err)
 		if got != want {
 			t.Errorf("\n%s -> %s != %s\n", function, got, want)
 		}
@@ -118,6 +180,63 @@ func TestUTM_ToMGRS(t *testing.T) {
 	}
 }
 
+func TestUTM_Format(t *testing.T) {
+
+	var tests = []struct {
+		utm      UTM      // in
+		decimals int      // in
+		rounding Rounding // in
+		format   string   // out
+	}{
+		// positive tests
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.958, Northing: 5756497.742}, 0, RoundHalfUp, "32U 398974 5756498"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.958, Northing: 5756497.742}, 0, Truncate, "32U 398973 5756497"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.958, Northing: 5756497.742}, 2, RoundHalfUp, "32U 398973.96 5756497.74"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.958, Northing: 5756497.742}, 2, Truncate, "32U 398973.95 5756497.74"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.5, Northing: 5756497.29}, 0, RoundHalfUp, "32U 398974 5756497"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.5, Northing: 5756497.29}, 2, Truncate, "32U 398973.50 5756497.29"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.5, Northing: 5756497.29}, -1, Truncate, "32U 398973 5756497"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.9999999, Northing: 5756497.9999999}, 0, Truncate, "32U 398973 5756497"}, // just below a grid line
+	}
+
+	for _, test := range tests {
+		function := fmt.Sprintf("utm = %#v, Format(%d, %d)", test.utm, test.decimals, test.rounding)
+		got := test.utm.Format(test.decimals, test.rounding)
+		want := test.format
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestUTM_ToMGRSRounding(t *testing.T) {
+
+	var tests = []struct {
+		utm      UTM      // in
+		accuracy int      // in
+		rounding Rounding // in
+		mgrs     string   // out
+	}{
+		// positive tests
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.958, Northing: 5756497.742}, 1, Truncate, "32ULC9897356497"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.958, Northing: 5756497.742}, 1, RoundHalfUp, "32ULC9897456498"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.958, Northing: 5756497.742}, 100, Truncate, "32ULC989564"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.958, Northing: 5756497.742}, 100, RoundHalfUp, "32ULC990565"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 399999.6, Northing: 5756497.742}, 1, Truncate, "32ULC9999956497"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 399999.6, Northing: 5756497.742}, 1, RoundHalfUp, "32UMC0000056498"}, // next 100k square
+	}
+
+	for _, test := range tests {
+		mgrs := test.utm.ToMGRSRounding(test.accuracy, test.rounding)
+		function := fmt.Sprintf("utm = %#v, ToMGRSRounding(%d, %d)", test.utm, test.accuracy, test.rounding)
+		got := string(mgrs)
+		want := test.mgrs
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
 func TestMGRS_ToUTM(t *testing.T) {
 
 	var tests = []struct {
@@ -139,6 +258,7 @@ func TestMGRS_ToUTM(t *testing.T) {
 		{"30NYF6799300000", UTM{ZoneNumber: 30, ZoneLetter: 'N', Easting: 767993, Northing: 0}, 1, nil},
 		// negative tests
 		{"", UTM{}, 0, fmt.Errorf("invalid empty mgrs string")},
+		{"ZAH0000000000", UTM{}, 0, fmt.Errorf("polar mgrs not covered by utm (use mgrs.ToUPS()), mgrs = ZAH0000000000")},
 	}
 
 	for _, test := range tests {
@@ -165,13 +285,13 @@ func TestLL_ToMGRS(t *testing.T) {
 		{LL{Lat: 51.95, Lon: 7.53}, 100, "32ULC989564", nil},
 		{LL{Lat: -19.887495, Lon: -43.932663}, 1, "23KPU1173300614", nil},
 		{LL{Lat: 0.0, Lon: -0.592328}, 1, "30NYF6799300000", nil},
+		{LL{Lat: 88.95, Lon: 7.53}, 100, "ZAF152844", nil},  // polar region (UPS)
+		{LL{Lat: -88.95, Lon: 7.53}, 100, "BAP152155", nil}, // polar region (UPS)
 		// negative tests
 		{LL{Lat: 51.95, Lon: 188.53}, 100, "", fmt.Errorf("invalid longitude, lon = 188.53")},
 		{LL{Lat: 51.95, Lon: -188.53}, 100, "", fmt.Errorf("invalid longitude, lon = -188.53")},
 		{LL{Lat: 99.95, Lon: 7.53}, 100, "", fmt.Errorf("invalid latitude, lat = 99.95")},
 		{LL{Lat: -99.95, Lon: 7.53}, 100, "", fmt.Errorf("invalid latitude, lat = -99.95")},
-		{LL{Lat: 88.95, Lon: 7.53}, 100, "", fmt.Errorf("polar regions below 80°S and above 84°N not supported, lat = 88.95")},
-		{LL{Lat: -88.95, Lon: 7.53}, 100, "", fmt.Errorf("polar regions below 80°S and above 84°N not supported, lat = -88.95")},
 	}
 
 	for _, test := range tests {
@@ -201,7 +321,7 @@ func TestMGRS_ToLL(t *testing.T) {
 		{"31UGT03734554", LL{Lat: 51.823490, Lon: 5.956335}, 10, nil},
 		{"30NYF6799300000", LL{Lat: 0.0, Lon: -0.592328}, 1, nil},
 		// negative tests
-		{"32ULC9897356497CORRUPT", LL{}, 0, fmt.Errorf("error <uneven number of digits, mgrs = 32ULC9897356497CORRUPT> at mgrs.ToUTM()")},
+		{"32ULC9897356497CORRUPT", LL{}, 0, fmt.Errorf("error <unexpected character 'C' at position 16, mgrs = 32ULC9897356497CORRUPT> at mgrs.ToUTM()")},
 	}
 
 	for _, test := range tests {
@@ -231,9 +351,21 @@ func ExampleLL_ToUTM() {
 
 	ll := LL{Lon: -115.08209766, Lat: 36.23612346}
 	utm := ll.ToUTM()
+	fmt.Printf("%s -> %s\n", ll, utm.Format(2, RoundHalfUp))
+	// Output:
+	// 36.236123 -115.082098 -> 11S 672349.00 4011844.00
+}
+
+func ExampleLL_ToUTMZone() {
+
+	ll := LL{Lat: 52.5186, Lon: 13.4083}
+	utm, err := ll.ToUTMZone(32, NorthernHemisphere)
+	if err != nil {
+		log.Fatalf("error <%v> at ll.ToUTMZone()", err)
+	}
 	fmt.Printf("%s -> %s\n", ll, utm)
 	// Output:
-	// 36.236123 -115.082098 -> 11S 672349 4011843
+	// 52.518600 13.408300 -> 32U 799046 5827858
 }
 
 func ExampleUTM_ToMGRS() {
@@ -0,0 +1,90 @@
+/*
+Purpose:
+- Meridian convergence (grid north vs. true north) and point scale factor of UTM positions
+
+Description:
+- The meridian convergence is the angle from true north to grid north (clockwise positive). It is
+  positive east of the central meridian in the northern hemisphere and west of it in the southern hemisphere.
+- The point scale factor k is the ratio of grid distance to ellipsoid distance at the position
+  (0.9996 on the central meridian, about 1.0004 at the zone border on the equator).
+- Available for LL (zone as ToUTM), UTM (zone of the coordinate) and MGRS (south-west corner of the MGRS cell).
+
+Remarks:
+- Calculated with the Krüger series (derivative of the forward projection, accuracy far better than needed).
+- Grid bearing = true bearing - convergence. Grid distance = ellipsoid distance * scale.
+
+Links:
+- https://arxiv.org/abs/1002.1417 (C. F. F. Karney, Transverse Mercator with an accuracy of a few nanometers)
+- https://en.wikipedia.org/wiki/Transverse_Mercator_projection#Convergence
+*/
+
+package coco
+
+import (
+	"fmt"
+)
+
+// UTMFactors defines UTM coordinate together with meridian convergence and point scale factor
+type UTMFactors struct {
+	UTM         UTM
+	Convergence float64 // meridian convergence in degrees (grid north clockwise from true north)
+	Scale       float64 // point scale factor
+}
+
+/*
+String returns stringified UTMFactors object (UTM rounded to full meters, convergence in degrees, scale).
+*/
+func (f UTMFactors) String() string {
+
+	return fmt.Sprintf("%s %.6f %.8f", f.UTM, f.Convergence, f.Scale)
+}
+
+/*
+ToUTMFactors converts Lon Lat to UTM (WGS84 ellipsoid) and returns meridian convergence and point scale factor.
+The latitude must be within the UTM coverage (80°S to 84°N).
+*/
+func (ll LL) ToUTMFactors() (UTMFactors, error) {
+
+	if !(ll.Lon >= -180 && ll.Lon <= 180) {
+		return UTMFactors{}, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if !(ll.Lat >= -80 && ll.Lat <= 84) {
+		return UTMFactors{}, fmt.Errorf("latitude not covered by utm (-80..84), lat = %v", ll.Lat)
+	}
+
+	utm := ll.ToUTM()
+	convergence, scale := utmProjection(EllipsoidWGS84, Krueger, utm.ZoneNumber).convergenceScale(ll.Lat, ll.Lon)
+
+	return UTMFactors{UTM: utm, Convergence: convergence, Scale: scale}, nil
+}
+
+/*
+Factors returns meridian convergence and point scale factor of the UTM coordinate (WGS84 ellipsoid, zone of the coordinate).
+*/
+func (utm UTM) Factors() (UTMFactors, error) {
+
+	if utm.ZoneNumber < 1 || utm.ZoneNumber > 60 {
+		return UTMFactors{}, fmt.Errorf("invalid zone number, zone number = %v", utm.ZoneNumber)
+	}
+
+	ll, err := utm.ToLL()
+	if err != nil {
+		return UTMFactors{}, fmt.Errorf("error <%w> at utm.ToLL(), utm = %s", err, utm)
+	}
+	convergence, scale := utmProjection(EllipsoidWGS84, Krueger, utm.ZoneNumber).convergenceScale(ll.Lat, ll.Lon)
+
+	return UTMFactors{UTM: utm, Convergence: convergence, Scale: scale}, nil
+}
+
+/*
+Factors returns UTM, meridian convergence and point scale factor of the south-west corner of the MGRS cell (WGS84 ellipsoid).
+*/
+func (mgrs MGRS) Factors() (UTMFactors, error) {
+
+	utm, _, err := mgrs.ToUTM()
+	if err != nil {
+		return UTMFactors{}, fmt.Errorf("error <%w> at mgrs.ToUTM(), mgrs = %s", err, mgrs)
+	}
+
+	return utm.Factors()
+}
@@ -0,0 +1,147 @@
+/*
+Purpose:
+- Meridian convergence (grid north vs. true north) and point scale factor of UTM positions
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"testing"
+)
+
+func TestLL_ToUTMFactors(t *testing.T) {
+
+	var tests = []struct {
+		ll      LL     // in
+		factors string // out
+		err     error  // out
+	}{
+		// positive tests
+		{LL{Lat: 52.52, Lon: 13.405}, "33U 391779 5820072 -1.265859 0.99974376", nil},
+		{LL{Lat: 0, Lon: 3}, "31N 500000 0 0.000000 0.99960000", nil}, // central meridian
+		{LL{Lat: 0, Lon: 0}, "31N 166021 0 0.000000 1.00098106", nil},
+		{LL{Lat: -33.9, Lon: 18.4}, "34H 259583 6245888 1.450833 1.00031259", nil}, // southern hemisphere, west of central meridian
+		{LL{Lat: 60, Lon: 11.9}, "32V 661721 6654957 2.512012 0.99992052", nil},    // Norway exception (zone 32)
+		{LL{Lat: 83, Lon: 20}, "33X 567946 9219404 4.962918 0.99965640", nil},      // Svalbard exception (zone 33)
+		{LL{Lat: 40.6, Lon: -73.8}, "18T 601531 4495047 0.780996 0.99972688", nil},
+		{LL{Lat: 84, Lon: 20}, "33X 558278 9330624 4.972747 0.99964149", nil}, // northern limit of utm
+		// negative tests
+		{LL{Lat: 88, Lon: 10}, UTMFactors{}.String(), fmt.Errorf("latitude not covered by utm (-80..84), lat = 88")},
+		{LL{Lat: -80.5, Lon: 10}, UTMFactors{}.String(), fmt.Errorf("latitude not covered by utm (-80..84), lat = -80.5")},
+		{LL{Lat: 50, Lon: 181}, UTMFactors{}.String(), fmt.Errorf("invalid longitude, lon = 181")},
+		{LL{Lat: math.NaN(), Lon: 10}, UTMFactors{}.String(), fmt.Errorf("latitude not covered by utm (-80..84), lat = NaN")},
+		{LL{Lat: 50, Lon: math.NaN()}, UTMFactors{}.String(), fmt.Errorf("invalid longitude, lon = NaN")},
+	}
+
+	for _, test := range tests {
+		factors, err := test.ll.ToUTMFactors()
+		function := fmt.Sprintf("%#v.ToUTMFactors()", test.ll)
+		got := fmt.Sprintf("%s %v", factors, err)
+		want := fmt.Sprintf("%s %v", test.factors, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_ToUTMFactorsNumerical(t *testing.T) {
+
+	// compare with grid distance and grid bearing of a short (1 m) geodesic
+	for _, ll := range []LL{{Lat: 52.52, Lon: 13.405}, {Lat: -33.9, Lon: 18.4}, {Lat: 83, Lon: 20}} {
+		factors, err := ll.ToUTMFactors()
+		if err != nil {
+			t.Fatalf("error <%v> at ll.ToUTMFactors()", err)
+		}
+		destination, _, err := ll.Destination(45, 1)
+		if err != nil {
+			t.Fatalf("error <%v> at ll.Destination()", err)
+		}
+		utm, err := destination.ToUTMZone(factors.UTM.ZoneNumber, factors.UTM.Hemisphere())
+		if err != nil {
+			t.Fatalf("error <%v> at ll.ToUTMZone()", err)
+		}
+		dx := utm.Easting - factors.UTM.Easting
+		dy := utm.Northing - factors.UTM.Northing
+		got := fmt.Sprintf("%.6f %.5f", math.Hypot(dx, dy), radToDeg(math.Atan2(dx, dy)))
+		want := fmt.Sprintf("%.6f %.5f", factors.Scale, 45-factors.Convergence)
+		if got != want {
+			t.Errorf("\n%#v.ToUTMFactors() -> %s != %s\n", ll, got, want)
+		}
+	}
+}
+
+func TestUTM_Factors(t *testing.T) {
+
+	var tests = []struct {
+		utm     UTM    // in
+		factors string // out
+		err     error  // out
+	}{
+		// positive tests
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 691000, Northing: 5335000}, "32U 691000 5335000 1.912779 1.00004827", nil},
+		{UTM{ZoneNumber: 31, ZoneLetter: 'N', Easting: 500000, Northing: 0}, "31N 500000 0 0.000000 0.99960000", nil},
+		// negative tests
+		{UTM{ZoneNumber: 0, ZoneLetter: 'U'}, UTMFactors{}.String(), fmt.Errorf("invalid zone number, zone number = 0")},
+	}
+
+	for _, test := range tests {
+		factors, err := test.utm.Factors()
+		function := fmt.Sprintf("%#v.Factors()", test.utm)
+		got := fmt.Sprintf("%s %v", factors, err)
+		want := fmt.Sprintf("%s %v", test.factors, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMGRS_Factors(t *testing.T) {
+
+	var tests = []struct {
+		mgrs    MGRS   // in
+		factors string // out
+		err     error  // out
+	}{
+		// positive tests
+		{"32UPU9156734734", "32U 691567 5334734 1.918289 1.00005093", nil},
+		{"33UUU9177920072", "33U 391779 5820072 -1.265862 0.99974376", nil},
+		// negative tests
+		{"ZAG0000099005", UTMFactors{}.String(), fmt.Errorf("error <polar mgrs not covered by utm (use mgrs.ToUPS()), mgrs = ZAG0000099005> at mgrs.ToUTM(), mgrs = ZAG0000099005")},
+		{"32UXX", UTMFactors{}.String(), fmt.Errorf("error <invalid 100k column letter 'X' for zone 32U at position 4, mgrs = 32UXX> at mgrs.ToUTM(), mgrs = 32UXX")},
+	}
+
+	for _, test := range tests {
+		factors, err := test.mgrs.Factors()
+		function := fmt.Sprintf("MGRS(%q).Factors()", test.mgrs)
+		got := fmt.Sprintf("%s %v", factors, err)
+		want := fmt.Sprintf("%s %v", test.factors, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleMGRS_Factors() {
+
+	// Munich: convert a true bearing (e.g. from the geodesic) to a grid bearing for the map
+	factors, err := MGRS("32UPU9156734734").Factors()
+	if err != nil {
+		log.Printf("error <%v> at mgrs.Factors()", err)
+		return
+	}
+	trueBearing := 10.0
+	fmt.Printf("convergence : %.2f°\n", factors.Convergence)
+	fmt.Printf("scale       : %.6f\n", factors.Scale)
+	fmt.Printf("grid bearing: %.2f°\n", trueBearing-factors.Convergence)
+
+	// Output:
+	// convergence : 1.92°
+	// scale       : 1.000051
+	// grid bearing: 8.08°
+}
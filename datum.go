@@ -0,0 +1,301 @@
+/*
+Purpose:
+- Geodetic datums and datum transformations (Lon Lat <-> ECEF <-> Lon Lat)
+
+Description:
+- Datum shifts through geocentric (earth-centered, earth-fixed) cartesian coordinates with
+  Helmert 7-parameter and Molodensky-Badekas 10-parameter transformations.
+- Built-in table of common datums with their shift to WGS84 (ED50, DHDN, NAD27, OSGB36, Pulkovo 1942, TM65, ...).
+
+Remarks:
+- Rotations are given in arc seconds, the scale in ppm (parts per million).
+- Two sign conventions for the rotations exist: position vector (EPSG 9606, PROJ towgs84)
+  and coordinate frame (EPSG 9607). Both are supported.
+- The shift parameters of the built-in datums are mean values (accuracy of a few meters).
+  For higher accuracy use regional parameters or grid based transformations (e.g. BeTA2007, OSTN15).
+- The inverse transformation is calculated exactly (inverse rotation matrix), not by negating the parameters.
+
+Links:
+- https://epsg.org/guidance-notes.html (EPSG Guidance Note 7-2, chapter 4.2)
+- https://proj.org/operations/transformations/helmert.html
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// HelmertConvention defines the sign convention of the Helmert rotation parameters
+type HelmertConvention int
+
+// Helmert rotation conventions
+const (
+	PositionVector  HelmertConvention = iota // EPSG 9606, PROJ towgs84 (default)
+	CoordinateFrame                          // EPSG 9607, rotations with opposite sign
+)
+
+// Helmert defines a 7-parameter (Helmert) or 10-parameter (Molodensky-Badekas) transformation
+type Helmert struct {
+	Tx, Ty, Tz float64           // translation in meters
+	Rx, Ry, Rz float64           // rotation in arc seconds
+	S          float64           // scale difference in ppm
+	Convention HelmertConvention // sign convention of the rotations
+	Px, Py, Pz float64           // pivot point in meters (Molodensky-Badekas only, 0 for Helmert)
+}
+
+// Datum defines a geodetic datum by its ellipsoid and the transformation to WGS84
+type Datum struct {
+	Name      string
+	Ellipsoid Ellipsoid
+	ToWGS84   Helmert // transformation from the datum to WGS84
+}
+
+// ECEF defines geocentric cartesian coordinate (earth-centered, earth-fixed) in meters
+type ECEF struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// built-in datums
+var (
+	WGS84       = Datum{Name: "WGS84", Ellipsoid: EllipsoidWGS84}
+	ETRS89      = Datum{Name: "ETRS89", Ellipsoid: EllipsoidGRS80}
+	DHDN        = Datum{Name: "DHDN", Ellipsoid: EllipsoidBessel1841, ToWGS84: Helmert{Tx: 598.1, Ty: 73.7, Tz: 418.2, Rx: 0.202, Ry: 0.045, Rz: -2.455, S: 6.7}}
+	OSGB36      = Datum{Name: "OSGB36", Ellipsoid: EllipsoidAiry1830, ToWGS84: Helmert{Tx: 446.448, Ty: -125.157, Tz: 542.06, Rx: 0.15, Ry: 0.247, Rz: 0.842, S: -20.489}}
+	ED50        = Datum{Name: "ED50", Ellipsoid: EllipsoidHayford1924, ToWGS84: Helmert{Tx: -87, Ty: -98, Tz: -121}}
+	NAD27       = Datum{Name: "NAD27", Ellipsoid: EllipsoidClarke1866, ToWGS84: Helmert{Tx: -8, Ty: 160, Tz: 176}}
+	Pulkovo1942 = Datum{Name: "Pulkovo1942", Ellipsoid: EllipsoidKrassowsky1940, ToWGS84: Helmert{Tx: 23.92, Ty: -141.27, Tz: -80.9, Rx: 0, Ry: 0.35, Rz: 0.82, S: -0.12}}
+	TM65        = Datum{Name: "TM65", Ellipsoid: EllipsoidAiryModified, ToWGS84: Helmert{Tx: 482.5, Ty: -130.6, Tz: 564.6, Rx: -1.042, Ry: -0.214, Rz: -0.631, S: 8.15}}
+	Amersfoort  = Datum{Name: "Amersfoort", Ellipsoid: EllipsoidBessel1841, ToWGS84: Helmert{Tx: 565.417, Ty: 50.3319, Tz: 465.552, Rx: -0.398957, Ry: 0.343988, Rz: -1.8774, S: 4.0725}}
+	CH1903Datum = Datum{Name: "CH1903", Ellipsoid: EllipsoidBessel1841, ToWGS84: Helmert{Tx: 674.374, Ty: 15.056, Tz: 405.346}}
+)
+
+// datums holds the registry of known datums (key = upper case name or alias)
+var datums = map[string]Datum{
+	"WGS84":       WGS84,
+	"ETRS89":      ETRS89,
+	"DHDN":        DHDN,
+	"POTSDAM":     DHDN,
+	"OSGB36":      OSGB36,
+	"ED50":        ED50,
+	"NAD27":       NAD27,
+	"PULKOVO1942": Pulkovo1942,
+	"PULKOVO":     Pulkovo1942,
+	"SK42":        Pulkovo1942,
+	"TM65":        TM65,
+	"IRENET95":    ETRS89,
+	"CH1903":      CH1903Datum,
+	"CH1903+":     CH1903Datum,
+	"CHTRS95":     ETRS89,
+	"AMERSFOORT":  Amersfoort,
+}
+
+/*
+String returns the name of the datum.
+*/
+func (datum Datum) String() string {
+
+	return datum.Name
+}
+
+/*
+String returns stringified ECEF object (rounded to millimeters).
+*/
+func (ecef ECEF) String() string {
+
+	return fmt.Sprintf("%.3f %.3f %.3f", ecef.X, ecef.Y, ecef.Z)
+}
+
+/*
+DatumByName returns a built-in datum by (case insensitive) name or alias.
+*/
+func DatumByName(name string) (Datum, error) {
+
+	key := strings.ToUpper(strings.Replace(strings.TrimSpace(name), " ", "", -1))
+	datum, ok := datums[key]
+	if !ok {
+		return Datum{}, fmt.Errorf("unknown datum, name = %s", name)
+	}
+
+	return datum, nil
+}
+
+/*
+DatumNames returns the sorted names of all built-in datums.
+*/
+func DatumNames() []string {
+
+	unique := map[string]bool{}
+	for _, datum := range datums {
+		unique[datum.Name] = true
+	}
+
+	names := []string{}
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+/*
+ToECEF converts Lon Lat (and ellipsoidal height) to geocentric cartesian coordinate.
+ellipsoid holds the reference ellipsoid of the Lon Lat coordinate.
+height holds the ellipsoidal height in meters.
+*/
+func (ll LL) ToECEF(ellipsoid Ellipsoid, height float64) ECEF {
+
+	latRad := degToRad(ll.Lat)
+	lonRad := degToRad(ll.Lon)
+	N := ellipsoid.RadiusOfCurvature(ll.Lat)
+
+	ecef := ECEF{}
+	ecef.X = (N + height) * math.Cos(latRad) * math.Cos(lonRad)
+	ecef.Y = (N + height) * math.Cos(latRad) * math.Sin(lonRad)
+	ecef.Z = (N*(1-ellipsoid.E2()) + height) * math.Sin(latRad)
+
+	return ecef
+}
+
+/*
+ToLL converts geocentric cartesian coordinate to Lon Lat and ellipsoidal height (iterative).
+ellipsoid holds the reference ellipsoid of the Lon Lat coordinate.
+*/
+func (ecef ECEF) ToLL(ellipsoid Ellipsoid) (LL, float64) {
+
+	e2 := ellipsoid.E2()
+	p := math.Hypot(ecef.X, ecef.Y)
+
+	ll := LL{}
+	ll.Lon = radToDeg(math.Atan2(ecef.Y, ecef.X))
+
+	if p < 1e-9 {
+		// on the polar axis
+		ll.Lat = 90
+		if ecef.Z < 0 {
+			ll.Lat = -90
+		}
+		return ll, math.Abs(ecef.Z) - ellipsoid.B()
+	}
+
+	latRad := math.Atan2(ecef.Z, p*(1-e2))
+	for i := 0; i < 20; i++ {
+		sinLat := math.Sin(latRad)
+		N := ellipsoid.A / math.Sqrt(1-e2*sinLat*sinLat)
+		next := math.Atan2(ecef.Z+e2*N*sinLat, p)
+		if math.Abs(next-latRad) < 1e-14 {
+			latRad = next
+			break
+		}
+		latRad = next
+	}
+	ll.Lat = radToDeg(latRad)
+
+	// height formula without division by cos(lat) (stable near the poles)
+	sinLat := math.Sin(latRad)
+	height := p*math.Cos(latRad) + ecef.Z*sinLat - ellipsoid.A*math.Sqrt(1-e2*sinLat*sinLat)
+
+	return ll, height
+}
+
+/*
+matrix returns the rotation and scale matrix (1+s) * R of the transformation (position vector convention).
+*/
+func (h Helmert) matrix() [3][3]float64 {
+
+	arcsec := math.Pi / (180 * 3600)
+	rx := h.Rx * arcsec
+	ry := h.Ry * arcsec
+	rz := h.Rz * arcsec
+	if h.Convention == CoordinateFrame {
+		rx, ry, rz = -rx, -ry, -rz
+	}
+	m := 1 + h.S*1e-6
+
+	return [3][3]float64{
+		{m, -m * rz, m * ry},
+		{m * rz, m, -m * rx},
+		{-m * ry, m * rx, m},
+	}
+}
+
+/*
+Transform applies the transformation to a geocentric cartesian coordinate.
+*/
+func (h Helmert) Transform(ecef ECEF) ECEF {
+
+	r := h.matrix()
+	x := ecef.X - h.Px
+	y := ecef.Y - h.Py
+	z := ecef.Z - h.Pz
+
+	return ECEF{
+		X: h.Tx + h.Px + r[0][0]*x + r[0][1]*y + r[0][2]*z,
+		Y: h.Ty + h.Py + r[1][0]*x + r[1][1]*y + r[1][2]*z,
+		Z: h.Tz + h.Pz + r[2][0]*x + r[2][1]*y + r[2][2]*z,
+	}
+}
+
+/*
+InverseTransform applies the exact inverse of the transformation to a geocentric cartesian coordinate.
+*/
+func (h Helmert) InverseTransform(ecef ECEF) ECEF {
+
+	r := h.matrix()
+	x := ecef.X - h.Tx - h.Px
+	y := ecef.Y - h.Ty - h.Py
+	z := ecef.Z - h.Tz - h.Pz
+
+	// inverse of the 3x3 matrix (adjugate / determinant)
+	det := r[0][0]*(r[1][1]*r[2][2]-r[1][2]*r[2][1]) -
+		r[0][1]*(r[1][0]*r[2][2]-r[1][2]*r[2][0]) +
+		r[0][2]*(r[1][0]*r[2][1]-r[1][1]*r[2][0])
+	inv := [3][3]float64{
+		{r[1][1]*r[2][2] - r[1][2]*r[2][1], r[0][2]*r[2][1] - r[0][1]*r[2][2], r[0][1]*r[1][2] - r[0][2]*r[1][1]},
+		{r[1][2]*r[2][0] - r[1][0]*r[2][2], r[0][0]*r[2][2] - r[0][2]*r[2][0], r[0][2]*r[1][0] - r[0][0]*r[1][2]},
+		{r[1][0]*r[2][1] - r[1][1]*r[2][0], r[0][1]*r[2][0] - r[0][0]*r[2][1], r[0][0]*r[1][1] - r[0][1]*r[1][0]},
+	}
+
+	return ECEF{
+		X: h.Px + (inv[0][0]*x+inv[0][1]*y+inv[0][2]*z)/det,
+		Y: h.Py + (inv[1][0]*x+inv[1][1]*y+inv[1][2]*z)/det,
+		Z: h.Pz + (inv[2][0]*x+inv[2][1]*y+inv[2][2]*z)/det,
+	}
+}
+
+/*
+Transform converts Lon Lat from one datum to another (through WGS84 and geocentric coordinates).
+The height is assumed to be 0 (ellipsoidal), the height change is dropped.
+from holds the datum of the Lon Lat coordinate.
+to holds the wanted datum.
+*/
+func (ll LL) Transform(from, to Datum) LL {
+
+	llTo, _ := ll.TransformHeight(from, to, 0)
+	return llTo
+}
+
+/*
+TransformHeight converts Lon Lat and ellipsoidal height from one datum to another.
+from holds the datum of the Lon Lat coordinate.
+to holds the wanted datum.
+height holds the ellipsoidal height in meters (relative to the ellipsoid of the from datum).
+*/
+func (ll LL) TransformHeight(from, to Datum, height float64) (LL, float64) {
+
+	if from == to {
+		return ll, height
+	}
+
+	ecef := ll.ToECEF(from.Ellipsoid, height)
+	ecef = from.ToWGS84.Transform(ecef)
+	ecef = to.ToWGS84.InverseTransform(ecef)
+
+	return ecef.ToLL(to.Ellipsoid)
+}
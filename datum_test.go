@@ -0,0 +1,157 @@
+/*
+Purpose:
+- Geodetic datums and datum transformations (Lon Lat <-> ECEF <-> Lon Lat)
+
+Description:
+- testing
+
+Remarks:
+- Helmert and Molodensky-Badekas examples from EPSG Guidance Note 7-2.
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestHelmert_Transform(t *testing.T) {
+
+	var tests = []struct {
+		helmert Helmert // in
+		ecef    ECEF    // in
+		want    ECEF    // out
+	}{
+		// positive tests
+		// WGS72 -> WGS84 (position vector, EPSG 9606)
+		{Helmert{Tz: 4.5, Rz: 0.554, S: 0.219}, ECEF{X: 3657660.66, Y: 255768.55, Z: 5201382.11}, ECEF{X: 3657660.774, Y: 255778.430, Z: 5201387.749}},
+		// La Canoa -> REGVEN (Molodensky-Badekas, coordinate frame, EPSG 9636)
+		{Helmert{Tx: -270.933, Ty: 115.599, Tz: -360.226, Rx: -5.266, Ry: -1.238, Rz: 2.381, S: -5.109, Convention: CoordinateFrame,
+			Px: 2464351.59, Py: -5783466.61, Pz: 974809.81},
+			ECEF{X: 2550408.96, Y: -5749912.26, Z: 1054891.11}, ECEF{X: 2550138.455, Y: -5749799.870, Z: 1054530.815}},
+	}
+
+	for _, test := range tests {
+		ecef := test.helmert.Transform(test.ecef)
+		function := fmt.Sprintf("%#v.Transform(%s)", test.helmert, test.ecef)
+		got := ecef.String()
+		want := test.want.String()
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		// the inverse must be exact
+		inverse := test.helmert.InverseTransform(ecef)
+		if math.Abs(inverse.X-test.ecef.X) > 1e-6 || math.Abs(inverse.Y-test.ecef.Y) > 1e-6 || math.Abs(inverse.Z-test.ecef.Z) > 1e-6 {
+			t.Errorf("\n%#v.InverseTransform(%s) -> %s != %s\n", test.helmert, ecef, inverse, test.ecef)
+		}
+	}
+}
+
+func TestECEF_ToLL(t *testing.T) {
+
+	var tests = []struct {
+		ll     LL      // in
+		height float64 // in
+	}{
+		// positive tests
+		{LL{Lat: 51.95, Lon: 7.53}, 0},
+		{LL{Lat: -33.857, Lon: 151.215}, 1000},
+		{LL{Lat: 89.9999, Lon: -120}, -50},
+		{LL{Lat: 0, Lon: 180}, 8848},
+	}
+
+	for _, test := range tests {
+		ecef := test.ll.ToECEF(EllipsoidBessel1841, test.height)
+		ll, height := ecef.ToLL(EllipsoidBessel1841)
+		function := fmt.Sprintf("%#v.ToECEF(Bessel1841, %v).ToLL()", test.ll, test.height)
+		got := fmt.Sprintf("%s %.4f", ll, height)
+		want := fmt.Sprintf("%s %.4f", test.ll, test.height)
+		if ll.String() != test.ll.String() || math.Abs(height-test.height) > 1e-4 {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_Transform(t *testing.T) {
+
+	var tests = []struct {
+		ll   LL    // in
+		from Datum // in
+		to   Datum // in
+		want LL    // out
+	}{
+		// positive tests
+		{LL{Lat: 52.0, Lon: 10.0}, DHDN, WGS84, LL{Lat: 51.99862837, Lon: 9.99878973}},
+		{LL{Lat: 51.5, Lon: -0.1}, OSGB36, WGS84, LL{Lat: 51.50051162, Lon: -0.10160916}},
+		{LL{Lat: 48.0, Lon: 2.0}, ED50, WGS84, LL{Lat: 47.99905830, Lon: 1.99872829}},
+		{LL{Lat: 40.0, Lon: -100.0}, NAD27, WGS84, LL{Lat: 40.00000948, Lon: -100.00041762}},
+		{LL{Lat: 55.75, Lon: 37.62}, Pulkovo1942, WGS84, LL{Lat: 55.75003643, Lon: 37.61812674}},
+		{LL{Lat: 52.0, Lon: 10.0}, WGS84, WGS84, LL{Lat: 52.0, Lon: 10.0}},
+	}
+
+	for _, test := range tests {
+		ll := test.ll.Transform(test.from, test.to)
+		function := fmt.Sprintf("%#v.Transform(%s, %s)", test.ll, test.from, test.to)
+		got := fmt.Sprintf("%.8f %.8f", ll.Lat, ll.Lon)
+		want := fmt.Sprintf("%.8f %.8f", test.want.Lat, test.want.Lon)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		// round trip with height (exact inverse)
+		llHeight, height := test.ll.TransformHeight(test.from, test.to, 0)
+		back, _ := llHeight.TransformHeight(test.to, test.from, height)
+		got = fmt.Sprintf("%.9f %.9f", back.Lat, back.Lon)
+		want = fmt.Sprintf("%.9f %.9f", test.ll.Lat, test.ll.Lon)
+		if got != want {
+			t.Errorf("\n%s -> round trip %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestDatumByName(t *testing.T) {
+
+	var tests = []struct {
+		name  string // in
+		datum Datum  // out
+		err   error  // out
+	}{
+		// positive tests
+		{"DHDN", DHDN, nil},
+		{"potsdam", DHDN, nil},
+		{"Pulkovo 1942", Pulkovo1942, nil},
+		// negative tests
+		{"Tokyo", Datum{}, fmt.Errorf("unknown datum, name = Tokyo")},
+	}
+
+	for _, test := range tests {
+		datum, err := DatumByName(test.name)
+		function := fmt.Sprintf("DatumByName(%s)", test.name)
+		got := fmt.Sprintf("%v %v", datum, err)
+		want := fmt.Sprintf("%v %v", test.datum, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+
+	got := fmt.Sprintf("%v", DatumNames())
+	want := "[Amersfoort CH1903 DHDN ED50 ETRS89 NAD27 OSGB36 Pulkovo1942 TM65 WGS84]"
+	if got != want {
+		t.Errorf("\nDatumNames() -> %s != %s\n", got, want)
+	}
+}
+
+func ExampleLL_Transform() {
+
+	// DHDN (Potsdam datum) -> WGS84 -> UTM
+	ll := LL{Lat: 52.0, Lon: 10.0}.Transform(DHDN, WGS84)
+	fmt.Printf("%.6f %.6f\n", ll.Lat, ll.Lon)
+	fmt.Println(ll.ToUTM())
+
+	// Output:
+	// 51.998628 9.998790
+	// 32U 568569 5761357
+}
@@ -0,0 +1,151 @@
+/*
+Purpose:
+- Reference ellipsoids
+
+Description:
+- Ellipsoid models used by the coordinate conversions (WGS84, GRS80, Bessel 1841, ...).
+
+Remarks:
+- Parameters (semi-major axis, inverse flattening) according to the EPSG registry.
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Ellipsoid defines a reference ellipsoid by its semi-major axis and inverse flattening
+type Ellipsoid struct {
+	Name string
+	A    float64 // semi-major axis in meters
+	InvF float64 // inverse flattening (1/f)
+}
+
+// built-in reference ellipsoids
+var (
+	EllipsoidWGS84          = Ellipsoid{Name: "WGS84", A: 6378137.0, InvF: 298.257223563}
+	EllipsoidGRS80          = Ellipsoid{Name: "GRS80", A: 6378137.0, InvF: 298.257222101}
+	EllipsoidBessel1841     = Ellipsoid{Name: "Bessel1841", A: 6377397.155, InvF: 299.1528128}
+	EllipsoidHayford1924    = Ellipsoid{Name: "Hayford1924", A: 6378388.0, InvF: 297.0}
+	EllipsoidClarke1866     = Ellipsoid{Name: "Clarke1866", A: 6378206.4, InvF: 294.978698214}
+	EllipsoidClarke1880     = Ellipsoid{Name: "Clarke1880", A: 6378249.145, InvF: 293.465}
+	EllipsoidKrassowsky1940 = Ellipsoid{Name: "Krassowsky1940", A: 6378245.0, InvF: 298.3}
+	EllipsoidAiry1830       = Ellipsoid{Name: "Airy1830", A: 6377563.396, InvF: 299.3249646}
+	EllipsoidAiryModified   = Ellipsoid{Name: "AiryModified", A: 6377340.189, InvF: 299.3249646}
+)
+
+// ellipsoids holds the registry of known ellipsoids (key = upper case name or alias)
+var ellipsoids = map[string]Ellipsoid{
+	"WGS84":          EllipsoidWGS84,
+	"GRS80":          EllipsoidGRS80,
+	"BESSEL1841":     EllipsoidBessel1841,
+	"BESSEL":         EllipsoidBessel1841,
+	"HAYFORD1924":    EllipsoidHayford1924,
+	"HAYFORD":        EllipsoidHayford1924,
+	"INTERNATIONAL":  EllipsoidHayford1924,
+	"CLARKE1866":     EllipsoidClarke1866,
+	"CLARKE1880":     EllipsoidClarke1880,
+	"KRASSOWSKY1940": EllipsoidKrassowsky1940,
+	"KRASSOWSKY":     EllipsoidKrassowsky1940,
+	"AIRY1830":       EllipsoidAiry1830,
+	"AIRY":           EllipsoidAiry1830,
+	"AIRYMODIFIED":   EllipsoidAiryModified,
+}
+
+/*
+String returns the name of the ellipsoid.
+*/
+func (e Ellipsoid) String() string {
+
+	return e.Name
+}
+
+/*
+F returns the flattening of the ellipsoid.
+*/
+func (e Ellipsoid) F() float64 {
+
+	return 1.0 / e.InvF
+}
+
+/*
+B returns the semi-minor axis of the ellipsoid in meters.
+*/
+func (e Ellipsoid) B() float64 {
+
+	return e.A * (1.0 - e.F())
+}
+
+/*
+E2 returns the first eccentricity squared of the ellipsoid.
+*/
+func (e Ellipsoid) E2() float64 {
+
+	f := e.F()
+	return f * (2.0 - f)
+}
+
+/*
+Ep2 returns the second eccentricity squared of the ellipsoid.
+*/
+func (e Ellipsoid) Ep2() float64 {
+
+	e2 := e.E2()
+	return e2 / (1.0 - e2)
+}
+
+/*
+N returns the third flattening of the ellipsoid.
+*/
+func (e Ellipsoid) N() float64 {
+
+	f := e.F()
+	return f / (2.0 - f)
+}
+
+/*
+RadiusOfCurvature returns the radius of curvature in the prime vertical at the given latitude.
+lat holds the latitude in degrees.
+*/
+func (e Ellipsoid) RadiusOfCurvature(lat float64) float64 {
+
+	sinLat := math.Sin(degToRad(lat))
+	return e.A / math.Sqrt(1.0-e.E2()*sinLat*sinLat)
+}
+
+/*
+EllipsoidByName returns a built-in ellipsoid by (case insensitive) name or alias.
+*/
+func EllipsoidByName(name string) (Ellipsoid, error) {
+
+	key := strings.ToUpper(strings.Replace(strings.TrimSpace(name), " ", "", -1))
+	e, ok := ellipsoids[key]
+	if !ok {
+		return Ellipsoid{}, fmt.Errorf("unknown ellipsoid, name = %s", name)
+	}
+
+	return e, nil
+}
+
+/*
+EllipsoidNames returns the sorted names of all built-in ellipsoids.
+*/
+func EllipsoidNames() []string {
+
+	unique := map[string]bool{}
+	for _, e := range ellipsoids {
+		unique[e.Name] = true
+	}
+
+	names := []string{}
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
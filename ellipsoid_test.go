@@ -0,0 +1,117 @@
+/*
+Purpose:
+- Reference ellipsoids
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEllipsoid_Parameters(t *testing.T) {
+
+	var tests = []struct {
+		ellipsoid Ellipsoid // in
+		b         string    // out
+		e2        string    // out
+	}{
+		// positive tests
+		{EllipsoidWGS84, "6356752.3142", "0.006694379990"},
+		{EllipsoidGRS80, "6356752.3141", "0.006694380023"},
+		{EllipsoidBessel1841, "6356078.9628", "0.006674372232"},
+		{EllipsoidHayford1924, "6356911.9461", "0.006722670022"},
+		{EllipsoidClarke1866, "6356583.8000", "0.006768657997"},
+		{EllipsoidKrassowsky1940, "6356863.0188", "0.006693421623"},
+	}
+
+	for _, test := range tests {
+		function := fmt.Sprintf("ellipsoid = %s, B() E2()", test.ellipsoid)
+		got := fmt.Sprintf("%.4f %.12f", test.ellipsoid.B(), test.ellipsoid.E2())
+		want := fmt.Sprintf("%s %s", test.b, test.e2)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestEllipsoidByName(t *testing.T) {
+
+	var tests = []struct {
+		name      string    // in
+		ellipsoid Ellipsoid // out
+		err       error     // out
+	}{
+		// positive tests
+		{"WGS84", EllipsoidWGS84, nil},
+		{"bessel", EllipsoidBessel1841, nil},
+		{"International", EllipsoidHayford1924, nil},
+		{"Clarke 1866", EllipsoidClarke1866, nil},
+		// negative tests
+		{"Everest", Ellipsoid{}, fmt.Errorf("unknown ellipsoid, name = Everest")},
+	}
+
+	for _, test := range tests {
+		ellipsoid, err := EllipsoidByName(test.name)
+		function := fmt.Sprintf("EllipsoidByName(%s)", test.name)
+		got := fmt.Sprintf("%v %v", ellipsoid, err)
+		want := fmt.Sprintf("%v %v", test.ellipsoid, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_ToUTMEllipsoid(t *testing.T) {
+
+	var tests = []struct {
+		ll        LL        // in
+		ellipsoid Ellipsoid // in
+		utm       UTM       // out
+	}{
+		// positive tests
+		{LL{Lat: 51.95, Lon: 7.53}, EllipsoidWGS84, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.96, Northing: 5756497.74}},
+		{LL{Lat: 51.95, Lon: 7.53}, EllipsoidGRS80, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973.96, Northing: 5756497.74}},
+		{LL{Lat: 51.95, Lon: 7.53}, EllipsoidBessel1841, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398986.31, Northing: 5755905.62}},
+		{LL{Lat: 51.95, Lon: 7.53}, EllipsoidHayford1924, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398969.09, Northing: 5756617.35}},
+	}
+
+	for _, test := range tests {
+		utm := test.ll.ToUTMEllipsoid(test.ellipsoid)
+		function := fmt.Sprintf("ll = %s, ToUTMEllipsoid(%s)", test.ll, test.ellipsoid)
+		got := utm.Format(2, RoundHalfUp)
+		want := test.utm.Format(2, RoundHalfUp)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestUTM_ToLLEllipsoid(t *testing.T) {
+
+	ll := LL{Lat: 48.858293, Lon: 2.294488}
+	for _, name := range EllipsoidNames() {
+		ellipsoid, err := EllipsoidByName(name)
+		if err != nil {
+			t.Fatalf("error <%v> at EllipsoidByName()", err)
+		}
+		mgrs, err := ll.ToMGRSEllipsoid(1, ellipsoid)
+		if err != nil {
+			t.Fatalf("error <%v> at ll.ToMGRSEllipsoid()", err)
+		}
+		llBack, _, err := mgrs.ToLLEllipsoid(ellipsoid)
+		if err != nil {
+			t.Fatalf("error <%v> at mgrs.ToLLEllipsoid()", err)
+		}
+		function := fmt.Sprintf("ll = %s, ellipsoid = %s, round trip via %s", ll, ellipsoid, mgrs)
+		got := fmt.Sprintf("%.4f %.4f", llBack.Lat, llBack.Lon)
+		want := fmt.Sprintf("%.4f %.4f", ll.Lat, ll.Lon)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
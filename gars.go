@@ -0,0 +1,268 @@
+/*
+Purpose:
+- GARS (Global Area Reference System) <-> Lon Lat, MGRS
+
+Description:
+- GARS cells (e.g. "006AG39") consist of a 3 digit longitudinal band (001-720, 30' each, starting at 180°W),
+  a 2 letter latitudinal band (AA-QZ, 30' each, starting at 90°S), an optional 15' quadrant (1-4)
+  and an optional 5' keypad (1-9).
+- Quadrants: 1 = north-west, 2 = north-east, 3 = south-west, 4 = south-east.
+- Keypad: 1 2 3 (north), 4 5 6, 7 8 9 (south), like a telephone keypad.
+
+Remarks:
+- Latitude band letters omit I and O (first letter A-Q, second letter A-Z).
+
+Links:
+- https://en.wikipedia.org/wiki/Global_Area_Reference_System
+*/
+
+package coco
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GARS defines a cell in Global Area Reference System notation
+type GARS string
+
+// GARSLevel defines the GARS cell size in minutes
+type GARSLevel int
+
+// GARS levels
+const (
+	GARS30Min GARSLevel = 30 // 30-minute cell (e.g. "006AG")
+	GARS15Min GARSLevel = 15 // 15-minute quadrant (e.g. "006AG3")
+	GARS5Min  GARSLevel = 5  // 5-minute keypad (e.g. "006AG39")
+)
+
+// garsLetters holds the latitude band letters (without I and O)
+const garsLetters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// GARS 5-minute unit resolution (units per degree)
+const garsUnits = 12
+
+/*
+length returns the number of characters of the GARS level, 0 for an invalid level.
+*/
+func (level GARSLevel) length() int {
+
+	switch level {
+	case GARS30Min:
+		return 5
+	case GARS15Min:
+		return 6
+	case GARS5Min:
+		return 7
+	}
+
+	return 0
+}
+
+/*
+ToGARS converts Lon Lat to GARS.
+level holds the wanted cell size (GARS30Min, GARS15Min or GARS5Min).
+*/
+func (ll LL) ToGARS(level GARSLevel) (GARS, error) {
+
+	if level.length() == 0 {
+		return "", fmt.Errorf("invalid gars level (30, 15 or 5 minutes), level = %d", level)
+	}
+	if !(ll.Lon >= -180 && ll.Lon <= 180) {
+		return "", fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if !(ll.Lat >= -90 && ll.Lat <= 90) {
+		return "", fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	// 5-minute units from 180°W and 90°S
+	lonVal := cellIndex(normalizeLon(ll.Lon)+180, garsUnits)
+	latVal := cellIndex(ll.Lat+90, garsUnits)
+
+	// the north pole is encoded into the cell below
+	if latVal >= 180*garsUnits {
+		latVal = 180*garsUnits - 1
+	}
+
+	lonBand := lonVal / 6
+	latBand := latVal / 6
+	gars := fmt.Sprintf("%03d%c%c", lonBand+1, garsLetters[latBand/len(garsLetters)], garsLetters[latBand%len(garsLetters)])
+
+	if level == GARS30Min {
+		return GARS(gars), nil
+	}
+
+	// quadrant (15 minutes): 1 2 (north), 3 4 (south)
+	lonUnit := lonVal % 6
+	latUnit := latVal % 6
+	quadrant := (1-latUnit/3)*2 + lonUnit/3 + 1
+	gars += strconv.Itoa(quadrant)
+
+	if level == GARS15Min {
+		return GARS(gars), nil
+	}
+
+	// keypad (5 minutes): 1 2 3 (north), 4 5 6, 7 8 9 (south)
+	keypad := (2-latUnit%3)*3 + lonUnit%3 + 1
+	gars += strconv.Itoa(keypad)
+
+	return GARS(gars), nil
+}
+
+/*
+Validate checks the GARS string (band number 001-720, band letters, quadrant 1-4, keypad 1-9, case insensitive).
+*/
+func (gars GARS) Validate() error {
+
+	_, _, _, err := gars.units()
+
+	return err
+}
+
+/*
+Level returns the cell size of the GARS cell (0 for an invalid cell).
+*/
+func (gars GARS) Level() GARSLevel {
+
+	_, _, level, err := gars.units()
+	if err != nil {
+		return 0
+	}
+
+	return level
+}
+
+/*
+units parses the GARS string and returns the south-west corner in 5-minute units and the level.
+*/
+func (gars GARS) units() (int, int, GARSLevel, error) {
+
+	s := strings.ToUpper(string(gars))
+
+	level := GARSLevel(0)
+	for _, candidate := range []GARSLevel{GARS30Min, GARS15Min, GARS5Min} {
+		if len(s) == candidate.length() {
+			level = candidate
+		}
+	}
+	if level == 0 {
+		return 0, 0, 0, fmt.Errorf("invalid gars length (5, 6 or 7 characters), gars = %s", gars)
+	}
+
+	lonBand, err := strconv.Atoi(s[0:3])
+	if err != nil || strings.ContainsAny(s[0:3], "+- ") || lonBand < 1 || lonBand > 720 {
+		return 0, 0, 0, fmt.Errorf("invalid longitudinal band (001-720), gars = %s", gars)
+	}
+
+	first := strings.IndexByte(garsLetters, s[3])
+	second := strings.IndexByte(garsLetters, s[4])
+	latBand := first*len(garsLetters) + second
+	if first < 0 || second < 0 || latBand >= 360 {
+		return 0, 0, 0, fmt.Errorf("invalid latitudinal band (AA-QZ), gars = %s", gars)
+	}
+
+	lonVal := (lonBand - 1) * 6
+	latVal := latBand * 6
+
+	if level != GARS30Min {
+		quadrant := int(s[5] - '0')
+		if quadrant < 1 || quadrant > 4 {
+			return 0, 0, 0, fmt.Errorf("invalid quadrant (1-4), gars = %s", gars)
+		}
+		lonVal += (quadrant - 1) % 2 * 3
+		latVal += (1 - (quadrant-1)/2) * 3
+	}
+
+	if level == GARS5Min {
+		keypad := int(s[6] - '0')
+		if keypad < 1 || keypad > 9 {
+			return 0, 0, 0, fmt.Errorf("invalid keypad (1-9), gars = %s", gars)
+		}
+		lonVal += (keypad - 1) % 3
+		latVal += 2 - (keypad-1)/3
+	}
+
+	return lonVal, latVal, level, nil
+}
+
+/*
+Bounds returns the bounding box of the GARS cell.
+*/
+func (gars GARS) Bounds() (BoundingBox, error) {
+
+	lonVal, latVal, level, err := gars.units()
+	if err != nil {
+		return BoundingBox{}, err
+	}
+
+	size := float64(level) / 60
+
+	bbox := BoundingBox{}
+	bbox.West = float64(lonVal)/garsUnits - 180
+	bbox.South = float64(latVal)/garsUnits - 90
+	bbox.East = bbox.West + size
+	bbox.North = bbox.South + size
+
+	return bbox, nil
+}
+
+/*
+Polygon returns the corners of the GARS cell as closed ring (counterclockwise, starting south-west).
+*/
+func (gars GARS) Polygon() ([]LL, error) {
+
+	bbox, err := gars.Bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	return []LL{
+		{Lat: bbox.South, Lon: bbox.West},
+		{Lat: bbox.South, Lon: bbox.East},
+		{Lat: bbox.North, Lon: bbox.East},
+		{Lat: bbox.North, Lon: bbox.West},
+		{Lat: bbox.South, Lon: bbox.West},
+	}, nil
+}
+
+/*
+ToLL converts GARS to Lon Lat (center of the cell).
+*/
+func (gars GARS) ToLL() (LL, error) {
+
+	bbox, err := gars.Bounds()
+	if err != nil {
+		return LL{}, err
+	}
+
+	return bbox.Center(), nil
+}
+
+/*
+ToMGRS converts GARS (center of the cell) to MGRS/UTMREF.
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+*/
+func (gars GARS) ToMGRS(accuracy int) (MGRS, error) {
+
+	ll, err := gars.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at gars.ToLL(), gars = %s", err, gars)
+	}
+
+	return ll.ToMGRS(accuracy)
+}
+
+/*
+ToGARS converts MGRS/UTMREF (south-west corner of the MGRS cell) to GARS.
+level holds the wanted cell size (GARS30Min, GARS15Min or GARS5Min).
+*/
+func (mgrs MGRS) ToGARS(level GARSLevel) (GARS, error) {
+
+	ll, _, err := mgrs.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at mgrs.ToLL(), mgrs = %s", err, mgrs)
+	}
+
+	return ll.ToGARS(level)
+}
@@ -0,0 +1,165 @@
+/*
+Purpose:
+- GARS (Global Area Reference System) <-> Lon Lat, MGRS
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"testing"
+)
+
+func TestLL_ToGARS(t *testing.T) {
+
+	var tests = []struct {
+		ll    LL        // in
+		level GARSLevel // in
+		gars  GARS      // out
+		err   error     // out
+	}{
+		// positive tests
+		{LL{Lat: -86.9, Lon: -177.1}, GARS30Min, "006AG", nil},
+		{LL{Lat: -86.9, Lon: -177.1}, GARS15Min, "006AG4", nil},
+		{LL{Lat: -86.9, Lon: -177.1}, GARS5Min, "006AG45", nil},
+		{LL{Lat: -86.95, Lon: -177.3}, GARS5Min, "006AG39", nil},
+		{LL{Lat: 0, Lon: 0}, GARS5Min, "361HN37", nil},
+		{LL{Lat: 51.95, Lon: 7.53}, GARS5Min, "376MV11", nil},
+		{LL{Lat: 38.9, Lon: -77.04}, GARS5Min, "206LT26", nil},
+		{LL{Lat: -90, Lon: -180}, GARS5Min, "001AA37", nil},
+		{LL{Lat: 90, Lon: 180}, GARS5Min, "001QZ11", nil}, // north pole and 180°E are encoded into the adjacent cell
+		// negative tests
+		{LL{Lat: 0, Lon: 0}, GARSLevel(10), "", fmt.Errorf("invalid gars level (30, 15 or 5 minutes), level = 10")},
+		{LL{Lat: 95, Lon: 0}, GARS30Min, "", fmt.Errorf("invalid latitude, lat = 95")},
+		{LL{Lat: math.NaN(), Lon: 0}, GARS30Min, "", fmt.Errorf("invalid latitude, lat = NaN")},
+		{LL{Lat: 0, Lon: math.NaN()}, GARS30Min, "", fmt.Errorf("invalid longitude, lon = NaN")},
+	}
+
+	for _, test := range tests {
+		gars, err := test.ll.ToGARS(test.level)
+		function := fmt.Sprintf("%#v.ToGARS(%d)", test.ll, test.level)
+		got := fmt.Sprintf("%s %v", gars, err)
+		want := fmt.Sprintf("%s %v", test.gars, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestGARS_Bounds(t *testing.T) {
+
+	var tests = []struct {
+		gars  GARS        // in
+		bbox  BoundingBox // out
+		ll    LL          // out
+		level GARSLevel   // out
+		err   error       // out
+	}{
+		// positive tests
+		{"006AG", BoundingBox{South: -87, West: -177.5, North: -86.5, East: -177}, LL{Lat: -86.75, Lon: -177.25}, GARS30Min, nil},
+		{"006ag3", BoundingBox{South: -87, West: -177.5, North: -86.75, East: -177.25}, LL{Lat: -86.875, Lon: -177.375}, GARS15Min, nil},
+		{"006AG39", BoundingBox{South: -87, West: -177.333333, North: -86.916667, East: -177.25}, LL{Lat: -86.958333, Lon: -177.291667}, GARS5Min, nil},
+		{"361HN37", BoundingBox{South: 0, West: 0, North: 0.083333, East: 0.083333}, LL{Lat: 0.041667, Lon: 0.041667}, GARS5Min, nil},
+		{"720QZ25", BoundingBox{South: 89.833333, West: 179.833333, North: 89.916667, East: 179.916667}, LL{Lat: 89.875, Lon: 179.875}, GARS5Min, nil},
+		// negative tests
+		{"000AA", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid longitudinal band (001-720), gars = 000AA")},
+		{"721AA", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid longitudinal band (001-720), gars = 721AA")},
+		{"+06AG", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid longitudinal band (001-720), gars = +06AG")},
+		{"006RA", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid latitudinal band (AA-QZ), gars = 006RA")},
+		{"006AI", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid latitudinal band (AA-QZ), gars = 006AI")},
+		{"006AG5", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid quadrant (1-4), gars = 006AG5")},
+		{"006AG30", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid keypad (1-9), gars = 006AG30")},
+		{"06AG39", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid longitudinal band (001-720), gars = 06AG39")},
+		{"006AG391", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid gars length (5, 6 or 7 characters), gars = 006AG391")},
+	}
+
+	for _, test := range tests {
+		bbox, err := test.gars.Bounds()
+		function := fmt.Sprintf("%s.Bounds()", test.gars)
+		got := fmt.Sprintf("%s %d %v", bbox, test.gars.Level(), err)
+		want := fmt.Sprintf("%s %d %v", test.bbox, test.level, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		ll, err := test.gars.ToLL()
+		function = fmt.Sprintf("%s.ToLL()", test.gars)
+		got = fmt.Sprintf("%s %v", ll, err)
+		want = fmt.Sprintf("%s %v", test.ll, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestGARS_Polygon(t *testing.T) {
+
+	polygon, err := GARS("361HN3").Polygon()
+	got := fmt.Sprintf("%v %v", polygon, err)
+	want := "[0.000000 0.000000 0.000000 0.250000 0.250000 0.250000 0.250000 0.000000 0.000000 0.000000] <nil>"
+	if got != want {
+		t.Errorf("\nGARS(361HN3).Polygon() -> %s != %s\n", got, want)
+	}
+
+	polygon, err = GARS("361HN").Polygon()
+	if len(polygon) != 5 || polygon[0] != polygon[4] || err != nil {
+		t.Errorf("\nGARS(361HN).Polygon() -> %v %v, closed ring with 5 corners expected\n", polygon, err)
+	}
+}
+
+func TestGARS_MGRS(t *testing.T) {
+
+	var tests = []struct {
+		gars GARS // in
+		mgrs MGRS // out
+	}{
+		{"361HN37", "31NAA7066404611"},
+		{"376MV11", "32ULC9979457408"},
+		{"206LT26", "18SUJ2288904886"},
+	}
+
+	for _, test := range tests {
+		mgrs, err := test.gars.ToMGRS(1)
+		function := fmt.Sprintf("%s.ToMGRS(1)", test.gars)
+		got := fmt.Sprintf("%s %v", mgrs, err)
+		want := fmt.Sprintf("%s %v", test.mgrs, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		// MGRS cell (south-west corner) back to the GARS cell
+		gars, err := mgrs.ToGARS(test.gars.Level())
+		function = fmt.Sprintf("%s.ToGARS(%d)", mgrs, test.gars.Level())
+		got = fmt.Sprintf("%s %v", gars, err)
+		want = fmt.Sprintf("%s %v", test.gars, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleLL_ToGARS() {
+
+	ll := LL{Lat: 38.9, Lon: -77.04}
+	gars, err := ll.ToGARS(GARS5Min)
+	if err != nil {
+		log.Printf("error <%v> at ll.ToGARS()", err)
+		return
+	}
+	mgrs, err := gars.ToMGRS(100)
+	if err != nil {
+		log.Printf("error <%v> at gars.ToMGRS()", err)
+		return
+	}
+	fmt.Println(gars)
+	fmt.Println(mgrs)
+
+	// Output:
+	// 206LT26
+	// 18SUJ228048
+}
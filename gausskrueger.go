@@ -0,0 +1,237 @@
+/*
+Purpose:
+- German Gauss-Krüger (DHDN, Bessel 1841) <-> Lon Lat, UTM, MGRS
+
+Description:
+- Gauss-Krüger coordinates (Rechtswert/Hochwert) in 3° or 6° meridian strips on the Bessel ellipsoid.
+  The Rechtswert carries the zone number as prefix (e.g. zone 3: "3477777" = 3 * 1000000 + 500000 - 22223).
+
+Remarks:
+- 3° strips: central meridian = zone * 3°, zone = round(lon / 3) (1-120, 0° = zone 120), scale 1.0.
+- 6° strips: central meridian = zone * 6° - 3°, zone = floor(lon / 6) + 1 (1-60), scale 1.0.
+- Western longitudes are counted eastwards from the Greenwich meridian (e.g. 3° strips: -9° = zone 117).
+- Conversion from/to WGS84 uses the Helmert transformation of the DHDN datum (accuracy about 3 m).
+  For cadastral accuracy the BeTA2007 grid transformation is required (not supported).
+
+Links:
+- https://de.wikipedia.org/wiki/Gauß-Krüger-Koordinatensystem
+- https://epsg.io/31467
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// StripWidth defines the width of the Gauss-Krüger meridian strips in degrees
+type StripWidth int
+
+// Gauss-Krüger meridian strip widths
+const (
+	Strip3 StripWidth = 3 // 3° strips (DHDN, West Germany)
+	Strip6 StripWidth = 6 // 6° strips
+)
+
+// GaussKrueger defines coordinate in Gauss-Krüger (DHDN)
+type GaussKrueger struct {
+	Strip      StripWidth // meridian strip width (Strip3 or Strip6)
+	Rechtswert float64    // easting with zone number prefix (zone * 1000000 + 500000 + x)
+	Hochwert   float64    // northing
+}
+
+/*
+String returns stringified GaussKrueger object (Rechtswert and Hochwert, rounded to meters).
+*/
+func (gk GaussKrueger) String() string {
+
+	return fmt.Sprintf("%.0f %.0f", roundValue(gk.Rechtswert, 1, RoundHalfUp), roundValue(gk.Hochwert, 1, RoundHalfUp))
+}
+
+/*
+Zone returns the zone number (prefix of the Rechtswert).
+*/
+func (gk GaussKrueger) Zone() int {
+
+	return int(math.Floor(gk.Rechtswert / 1000000))
+}
+
+/*
+maxZone returns the highest zone number of the strip width, 0 for an invalid strip width.
+*/
+func (strip StripWidth) maxZone() int {
+
+	switch strip {
+	case Strip3:
+		return 120
+	case Strip6:
+		return 60
+	}
+
+	return 0
+}
+
+/*
+gaussKruegerProjection returns the transverse Mercator parameters of the Gauss-Krüger zone.
+*/
+func gaussKruegerProjection(strip StripWidth, zone int) transverseMercator {
+
+	lon0 := float64(zone * 3)
+	if strip == Strip6 {
+		lon0 = float64(zone*6 - 3)
+	}
+
+	return transverseMercator{
+		ellipsoid:    EllipsoidBessel1841,
+		engine:       Krueger,
+		lon0:         lon0,
+		k0:           1.0,
+		falseEasting: float64(zone)*1000000 + 500000,
+	}
+}
+
+/*
+ToGaussKrueger converts Lon Lat (WGS84 datum) to Gauss-Krüger (DHDN), the zone is selected by the longitude.
+strip holds the meridian strip width (Strip3 or Strip6).
+*/
+func (ll LL) ToGaussKrueger(strip StripWidth) (GaussKrueger, error) {
+
+	llDHDN := ll.Transform(WGS84, DHDN)
+
+	zone := int(math.Floor(llDHDN.Lon/6)) + 1
+	if strip == Strip3 {
+		zone = int(math.Floor(llDHDN.Lon/3 + 0.5))
+	}
+	// zones are counted eastwards from the Greenwich meridian (0° = zone 120 of the 3° strips)
+	if zone < 1 {
+		zone += strip.maxZone()
+	}
+
+	return ll.ToGaussKruegerZone(strip, zone)
+}
+
+/*
+ToGaussKruegerZone converts Lon Lat (WGS84 datum) to Gauss-Krüger (DHDN) in the given zone
+(e.g. for data in the neighbouring zone).
+strip holds the meridian strip width (Strip3 or Strip6).
+zone holds the wanted zone number.
+*/
+func (ll LL) ToGaussKruegerZone(strip StripWidth, zone int) (GaussKrueger, error) {
+
+	if strip.maxZone() == 0 {
+		return GaussKrueger{}, fmt.Errorf("invalid strip width, strip = %d", strip)
+	}
+	if zone < 1 || zone > strip.maxZone() {
+		return GaussKrueger{}, fmt.Errorf("invalid zone number (1-%d), zone number = %d", strip.maxZone(), zone)
+	}
+	if ll.Lon < -180 || ll.Lon > 180 {
+		return GaussKrueger{}, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if ll.Lat < -90 || ll.Lat > 90 {
+		return GaussKrueger{}, fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	llDHDN := ll.Transform(WGS84, DHDN)
+
+	gk := GaussKrueger{Strip: strip}
+	gk.Rechtswert, gk.Hochwert = gaussKruegerProjection(strip, zone).forward(llDHDN.Lat, llDHDN.Lon)
+
+	return gk, nil
+}
+
+/*
+ToLL converts Gauss-Krüger (DHDN) to Lon Lat (WGS84 datum).
+*/
+func (gk GaussKrueger) ToLL() (LL, error) {
+
+	return gk.ToLLDatum(WGS84)
+}
+
+/*
+ToLLDatum converts Gauss-Krüger to Lon Lat.
+datum holds the wanted datum of the Lon Lat coordinate (e.g. WGS84 or DHDN).
+*/
+func (gk GaussKrueger) ToLLDatum(datum Datum) (LL, error) {
+
+	if gk.Strip.maxZone() == 0 {
+		return LL{}, fmt.Errorf("invalid strip width, strip = %d", gk.Strip)
+	}
+	zone := gk.Zone()
+	if zone < 1 || zone > gk.Strip.maxZone() {
+		return LL{}, fmt.Errorf("invalid zone number (1-%d) in rechtswert, rechtswert = %.0f", gk.Strip.maxZone(), gk.Rechtswert)
+	}
+
+	ll := LL{}
+	ll.Lat, ll.Lon = gaussKruegerProjection(gk.Strip, zone).inverse(gk.Rechtswert, gk.Hochwert)
+
+	return ll.Transform(DHDN, datum), nil
+}
+
+/*
+ToUTM converts Gauss-Krüger (DHDN) to UTM (WGS84).
+*/
+func (gk GaussKrueger) ToUTM() (UTM, error) {
+
+	ll, err := gk.ToLL()
+	if err != nil {
+		return UTM{}, fmt.Errorf("error <%v> at gk.ToLL(), gk = %s", err, gk)
+	}
+
+	return ll.ToUTM(), nil
+}
+
+/*
+ToMGRS converts Gauss-Krüger (DHDN) to MGRS/UTMREF (WGS84).
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+*/
+func (gk GaussKrueger) ToMGRS(accuracy int) (MGRS, error) {
+
+	ll, err := gk.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%v> at gk.ToLL(), gk = %s", err, gk)
+	}
+
+	return ll.ToMGRS(accuracy)
+}
+
+/*
+ParseGaussKrueger parses Gauss-Krüger Rechtswert and Hochwert (e.g. "3477777.12 5767789.34", "R 3477777 H 5767789").
+The Rechtswert must carry the zone number as prefix (at least 7 digits before the decimal point).
+s holds the Gauss-Krüger string.
+strip holds the meridian strip width (Strip3 or Strip6).
+*/
+func ParseGaussKrueger(s string, strip StripWidth) (GaussKrueger, error) {
+
+	replacer := strings.NewReplacer("R:", " ", "H:", " ", "R=", " ", "H=", " ", "R ", " ", "H ", " ", ",", " ", ";", " ")
+	fields := strings.Fields(replacer.Replace(strings.ToUpper(s) + " "))
+	if len(fields) != 2 {
+		return GaussKrueger{}, fmt.Errorf("invalid gauss-krueger format, gk = %s", s)
+	}
+
+	integer := strings.SplitN(fields[0], ".", 2)[0]
+	if len(integer) < 7 {
+		return GaussKrueger{}, fmt.Errorf("rechtswert without zone number prefix (7 digits expected), rechtswert = %s", fields[0])
+	}
+
+	rechtswert, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return GaussKrueger{}, fmt.Errorf("error <%v> at strconv.ParseFloat(), rechtswert string = %v", err, fields[0])
+	}
+	hochwert, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return GaussKrueger{}, fmt.Errorf("error <%v> at strconv.ParseFloat(), hochwert string = %v", err, fields[1])
+	}
+
+	gk := GaussKrueger{Strip: strip, Rechtswert: rechtswert, Hochwert: hochwert}
+	if strip.maxZone() == 0 {
+		return GaussKrueger{}, fmt.Errorf("invalid strip width, strip = %d", strip)
+	}
+	if gk.Zone() < 1 || gk.Zone() > strip.maxZone() {
+		return GaussKrueger{}, fmt.Errorf("invalid zone number (1-%d) in rechtswert, rechtswert = %s", strip.maxZone(), fields[0])
+	}
+
+	return gk, nil
+}
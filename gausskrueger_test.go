@@ -0,0 +1,176 @@
+/*
+Purpose:
+- German Gauss-Krüger (DHDN, Bessel 1841) <-> Lon Lat, UTM, MGRS
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"testing"
+)
+
+func TestLL_ToGaussKrueger(t *testing.T) {
+
+	var tests = []struct {
+		ll    LL           // in
+		strip StripWidth   // in
+		gk    GaussKrueger // out
+		err   error        // out
+	}{
+		// positive tests
+		{LL{Lat: 51.95, Lon: 7.53}, Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 3399006.159, Hochwert: 5758360.607}, nil},
+		{LL{Lat: 52.5186, Lon: 13.4081}, Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 4595685.135, Hochwert: 5821540.694}, nil},
+		{LL{Lat: 48.1372, Lon: 11.5756}, Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 4468520.806, Hochwert: 5333327.989}, nil},
+		{LL{Lat: 47.5, Lon: 9.0}, Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 3500076.830, Hochwert: 5262394.535}, nil},
+		{LL{Lat: 52.5186, Lon: 13.4081}, Strip6, GaussKrueger{Strip: Strip6, Rechtswert: 3392077.919, Hochwert: 5821794.981}, nil},
+		{LL{Lat: 51.0, Lon: 0.5}, Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 120535077.749, Hochwert: 5651762.961}, nil},  // Greenwich meridian = zone 120
+		{LL{Lat: 51.0, Lon: -0.5}, Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 120464880.240, Hochwert: 5651762.355}, nil}, // Greenwich meridian = zone 120
+		{LL{Lat: 51.95, Lon: -7.53}, Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 117600951.456, Hochwert: 5758346.167}, nil},
+		{LL{Lat: 40.7, Lon: -74.0}, Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 95583972.236, Hochwert: 4506965.187}, nil},
+		{LL{Lat: 51.0, Lon: -0.5}, Strip6, GaussKrueger{Strip: Strip6, Rechtswert: 60675430.344, Hochwert: 5654617.998}, nil},
+		{LL{Lat: 51.95, Lon: -7.53}, Strip6, GaussKrueger{Strip: Strip6, Rechtswert: 59600951.456, Hochwert: 5758346.167}, nil},
+		// negative tests
+		{LL{Lat: 51.95, Lon: 7.53}, StripWidth(4), GaussKrueger{}, fmt.Errorf("invalid strip width, strip = 4")},
+	}
+
+	for _, test := range tests {
+		gk, err := test.ll.ToGaussKrueger(test.strip)
+		function := fmt.Sprintf("%#v.ToGaussKrueger(%d)", test.ll, test.strip)
+		got := fmt.Sprintf("%d %.3f %.3f %v", gk.Strip, gk.Rechtswert, gk.Hochwert, err)
+		want := fmt.Sprintf("%d %.3f %.3f %v", test.gk.Strip, test.gk.Rechtswert, test.gk.Hochwert, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_ToGaussKruegerZone(t *testing.T) {
+
+	var tests = []struct {
+		ll    LL           // in
+		strip StripWidth   // in
+		zone  int          // in
+		gk    GaussKrueger // out
+		err   error        // out
+	}{
+		// positive tests
+		{LL{Lat: 51.95, Lon: 7.53}, Strip3, 3, GaussKrueger{Strip: Strip3, Rechtswert: 3399006.159, Hochwert: 5758360.607}, nil},
+		{LL{Lat: 51.95, Lon: 7.53}, Strip3, 2, GaussKrueger{Strip: Strip3, Rechtswert: 2605232.512, Hochwert: 5758448.013}, nil}, // neighbouring zone
+		// negative tests
+		{LL{Lat: 51.95, Lon: 7.53}, Strip6, 61, GaussKrueger{}, fmt.Errorf("invalid zone number (1-60), zone number = 61")},
+		{LL{Lat: 91.0, Lon: 7.53}, Strip3, 3, GaussKrueger{}, fmt.Errorf("invalid latitude, lat = 91")},
+	}
+
+	for _, test := range tests {
+		gk, err := test.ll.ToGaussKruegerZone(test.strip, test.zone)
+		function := fmt.Sprintf("%#v.ToGaussKruegerZone(%d, %d)", test.ll, test.strip, test.zone)
+		got := fmt.Sprintf("%d %.3f %.3f %v", gk.Strip, gk.Rechtswert, gk.Hochwert, err)
+		want := fmt.Sprintf("%d %.3f %.3f %v", test.gk.Strip, test.gk.Rechtswert, test.gk.Hochwert, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestGaussKrueger_ToLL(t *testing.T) {
+
+	var tests = []struct {
+		gk   GaussKrueger // in
+		ll   LL           // out
+		utm  UTM          // out
+		mgrs MGRS         // out
+		err  error        // out
+	}{
+		// positive tests
+		{GaussKrueger{Strip: Strip3, Rechtswert: 3399006.159, Hochwert: 5758360.607}, LL{Lat: 51.95, Lon: 7.53}, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398974, Northing: 5756498}, "32ULC9897356497", nil},
+		{GaussKrueger{Strip: Strip3, Rechtswert: 2605232.512, Hochwert: 5758448.013}, LL{Lat: 51.95, Lon: 7.53}, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398974, Northing: 5756498}, "32ULC9897356497", nil},
+		{GaussKrueger{Strip: Strip6, Rechtswert: 3392077.919, Hochwert: 5821794.981}, LL{Lat: 52.5186, Lon: 13.4081}, UTM{ZoneNumber: 33, ZoneLetter: 'U', Easting: 391986, Northing: 5819912}, "33UUU9198619911", nil},
+		// negative tests
+		{GaussKrueger{Strip: Strip3, Rechtswert: 477777, Hochwert: 5767789}, LL{}, UTM{}, "", fmt.Errorf("invalid zone number (1-120) in rechtswert, rechtswert = 477777")},
+	}
+
+	for _, test := range tests {
+		ll, err := test.gk.ToLL()
+		function := fmt.Sprintf("%#v.ToLL()", test.gk)
+		got := fmt.Sprintf("%s %v", ll, err)
+		want := fmt.Sprintf("%s %v", test.ll, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+		if err != nil {
+			continue
+		}
+
+		utm, err := test.gk.ToUTM()
+		function = fmt.Sprintf("%#v.ToUTM()", test.gk)
+		got = fmt.Sprintf("%s %v", utm, err)
+		want = fmt.Sprintf("%s %v", test.utm, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		mgrs, err := test.gk.ToMGRS(1)
+		function = fmt.Sprintf("%#v.ToMGRS(1)", test.gk)
+		got = fmt.Sprintf("%s %v", mgrs, err)
+		want = fmt.Sprintf("%s %v", test.mgrs, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestParseGaussKrueger(t *testing.T) {
+
+	var tests = []struct {
+		s     string       // in
+		strip StripWidth   // in
+		gk    GaussKrueger // out
+		err   error        // out
+	}{
+		// positive tests
+		{"3477777.12 5767789.34", Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 3477777.12, Hochwert: 5767789.34}, nil},
+		{"R 3477777 H 5767789", Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 3477777, Hochwert: 5767789}, nil},
+		{"r=3477777, h=5767789", Strip3, GaussKrueger{Strip: Strip3, Rechtswert: 3477777, Hochwert: 5767789}, nil},
+		{"3392078 5821795", Strip6, GaussKrueger{Strip: Strip6, Rechtswert: 3392078, Hochwert: 5821795}, nil},
+		// negative tests
+		{"477777 5767789", Strip3, GaussKrueger{}, fmt.Errorf("rechtswert without zone number prefix (7 digits expected), rechtswert = 477777")},
+		{"0477777 5767789", Strip3, GaussKrueger{}, fmt.Errorf("invalid zone number (1-120) in rechtswert, rechtswert = 0477777")},
+		{"3477777", Strip3, GaussKrueger{}, fmt.Errorf("invalid gauss-krueger format, gk = 3477777")},
+		{"3477777 5767789", StripWidth(0), GaussKrueger{}, fmt.Errorf("invalid strip width, strip = 0")},
+	}
+
+	for _, test := range tests {
+		gk, err := ParseGaussKrueger(test.s, test.strip)
+		function := fmt.Sprintf("ParseGaussKrueger(%s, %d)", test.s, test.strip)
+		got := fmt.Sprintf("%#v %v", gk, err)
+		want := fmt.Sprintf("%#v %v", test.gk, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleParseGaussKrueger() {
+
+	gk, err := ParseGaussKrueger("R 3399006 H 5758361", Strip3)
+	if err != nil {
+		log.Printf("error <%v> at ParseGaussKrueger()", err)
+		return
+	}
+	mgrs, err := gk.ToMGRS(1)
+	if err != nil {
+		log.Printf("error <%v> at gk.ToMGRS()", err)
+		return
+	}
+	fmt.Println(gk.Zone())
+	fmt.Println(mgrs)
+
+	// Output:
+	// 3
+	// 32ULC9897356498
+}
@@ -0,0 +1,1065 @@
+/*
+Purpose:
+- Geodesic distance and azimuths between two positions on the ellipsoid (inverse geodesic problem)
+- Destination from start point, azimuth and distance (direct geodesic problem)
+
+Description:
+- Solves the inverse geodesic problem with the algorithm of C. F. F. Karney (series expansions of
+  6th order in the third flattening, Newton iteration on the auxiliary sphere, astroid start value
+  for nearly antipodal points).
+- Returns the distance, the forward azimuth at the start point and the back azimuth at the end point.
+- Solves the direct geodesic problem with the same series (no iteration needed).
+- Available for LL, UTM and MGRS (south-west corner of the MGRS cell). UTM and MGRS destinations are
+  re-expressed in the zone of the destination.
+- Azimuths are geodetic (true north), not grid bearings. Mils are NATO mils (6400 per full circle).
+
+Remarks:
+- Accuracy about 15 nanometers on the WGS84 ellipsoid, also for nearly antipodal points.
+- Port of the inverse and direct solution of GeographicLib (MIT license) without area and geodesic scale.
+- Azimuths in degrees (0-360, clockwise from north).
+
+Links:
+- https://doi.org/10.1007/s00190-012-0578-z
+- https://geographiclib.sourceforge.io/
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+)
+
+// Geodesic defines the solution of the inverse geodesic problem
+type Geodesic struct {
+	Distance       float64 // distance in meters
+	ForwardAzimuth float64 // azimuth at the start point towards the end point (degrees)
+	BackAzimuth    float64 // azimuth at the end point towards the start point (degrees)
+}
+
+// geodesic series order and iteration parameters (GeographicLib)
+const (
+	geodesicOrder = 6
+	geodesicNC3x  = geodesicOrder * (geodesicOrder - 1) / 2
+	geodesicIt1   = 20               // Newton iterations
+	geodesicIt2   = geodesicIt1 + 63 // Newton and bisection iterations
+)
+
+// geodesic tolerances (GeographicLib)
+var (
+	geodesicTiny    = math.Sqrt(math.SmallestNonzeroFloat64 * (1 << 52)) // sqrt of the smallest normal number
+	geodesicTol0    = math.Nextafter(1, 2) - 1                           // machine epsilon
+	geodesicTol1    = 200 * geodesicTol0
+	geodesicTol2    = math.Sqrt(geodesicTol0)
+	geodesicXthresh = 1000 * geodesicTol2
+)
+
+// geodesic holds the ellipsoid dependent parameters of the geodesic calculations
+type geodesic struct {
+	a, f, f1, ep2, n, b float64
+	etol2               float64
+	a3x                 [geodesicOrder]float64
+	c3x                 [geodesicNC3x]float64
+}
+
+/*
+String returns stringified Geodesic object (distance in meters, forward and back azimuth in degrees).
+*/
+func (g Geodesic) String() string {
+
+	return fmt.Sprintf("%.3f %.6f %.6f", g.Distance, g.ForwardAzimuth, g.BackAzimuth)
+}
+
+/*
+DistanceTo solves the inverse geodesic problem between two positions (WGS84 ellipsoid).
+other holds the end point.
+*/
+func (ll LL) DistanceTo(other LL) (Geodesic, error) {
+
+	return ll.DistanceToEllipsoid(other, EllipsoidWGS84)
+}
+
+/*
+DistanceToEllipsoid solves the inverse geodesic problem between two positions.
+other holds the end point.
+ellipsoid holds the reference ellipsoid of both positions.
+*/
+func (ll LL) DistanceToEllipsoid(other LL, ellipsoid Ellipsoid) (Geodesic, error) {
+
+	for _, position := range []LL{ll, other} {
+		if math.IsNaN(position.Lon) || math.IsInf(position.Lon, 0) {
+			return Geodesic{}, fmt.Errorf("invalid longitude, lon = %v", position.Lon)
+		}
+		if !(position.Lat >= -90 && position.Lat <= 90) {
+			return Geodesic{}, fmt.Errorf("invalid latitude, lat = %v", position.Lat)
+		}
+	}
+
+	s12, azi1, azi2 := newGeodesic(ellipsoid).inverse(ll.Lat, ll.Lon, other.Lat, other.Lon)
+
+	return Geodesic{Distance: s12, ForwardAzimuth: azimuth360(azi1), BackAzimuth: azimuth360(azi2 + 180)}, nil
+}
+
+/*
+DistanceTo solves the inverse geodesic problem between two UTM coordinates (WGS84 ellipsoid).
+other holds the end point (any zone).
+*/
+func (utm UTM) DistanceTo(other UTM) (Geodesic, error) {
+
+	from, err := utm.ToLL()
+	if err != nil {
+		return Geodesic{}, fmt.Errorf("error <%w> at utm.ToLL(), utm = %s", err, utm)
+	}
+	to, err := other.ToLL()
+	if err != nil {
+		return Geodesic{}, fmt.Errorf("error <%w> at other.ToLL(), utm = %s", err, other)
+	}
+
+	return from.DistanceTo(to)
+}
+
+/*
+DistanceTo solves the inverse geodesic problem between two MGRS/UTMREF coordinates (south-west corner
+of the MGRS cells, WGS84 ellipsoid).
+other holds the end point.
+*/
+func (mgrs MGRS) DistanceTo(other MGRS) (Geodesic, error) {
+
+	from, _, err := mgrs.ToLL()
+	if err != nil {
+		return Geodesic{}, fmt.Errorf("error <%w> at mgrs.ToLL(), mgrs = %s", err, mgrs)
+	}
+	to, _, err := other.ToLL()
+	if err != nil {
+		return Geodesic{}, fmt.Errorf("error <%w> at other.ToLL(), mgrs = %s", err, other)
+	}
+
+	return from.DistanceTo(to)
+}
+
+/*
+Destination solves the direct geodesic problem (WGS84 ellipsoid) and returns the destination and the
+azimuth at the destination (direction of travel, degrees 0-360).
+azimuth holds the azimuth at the start point in degrees (clockwise from true north).
+distance holds the distance in meters.
+*/
+func (ll LL) Destination(azimuth, distance float64) (LL, float64, error) {
+
+	return ll.DestinationEllipsoid(azimuth, distance, EllipsoidWGS84)
+}
+
+/*
+DestinationEllipsoid solves the direct geodesic problem and returns the destination and the azimuth at
+the destination (direction of travel, degrees 0-360).
+azimuth holds the azimuth at the start point in degrees (clockwise from true north).
+distance holds the distance in meters.
+ellipsoid holds the reference ellipsoid of the position.
+*/
+func (ll LL) DestinationEllipsoid(azimuth, distance float64, ellipsoid Ellipsoid) (LL, float64, error) {
+
+	if math.IsNaN(ll.Lon) || math.IsInf(ll.Lon, 0) {
+		return LL{}, 0, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if !(ll.Lat >= -90 && ll.Lat <= 90) {
+		return LL{}, 0, fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+	if math.IsNaN(azimuth) || math.IsInf(azimuth, 0) {
+		return LL{}, 0, fmt.Errorf("invalid azimuth, azimuth = %v", azimuth)
+	}
+	if math.IsNaN(distance) || math.IsInf(distance, 0) {
+		return LL{}, 0, fmt.Errorf("invalid distance, distance = %v", distance)
+	}
+
+	lat2, lon2, azi2 := newGeodesic(ellipsoid).direct(ll.Lat, ll.Lon, azimuth, distance)
+
+	return LL{Lat: lat2, Lon: lon2}, azimuth360(azi2), nil
+}
+
+/*
+Destination solves the direct geodesic problem (WGS84 ellipsoid) and returns the destination in UTM
+(zone of the destination). Destinations in the polar regions (UPS) are rejected.
+azimuth holds the azimuth at the start point in degrees (clockwise from true north, not grid north).
+distance holds the distance in meters.
+*/
+func (utm UTM) Destination(azimuth, distance float64) (UTM, error) {
+
+	ll, err := utm.ToLL()
+	if err != nil {
+		return UTM{}, fmt.Errorf("error <%w> at utm.ToLL(), utm = %s", err, utm)
+	}
+	destination, _, err := ll.Destination(azimuth, distance)
+	if err != nil {
+		return UTM{}, err
+	}
+	if isPolarLat(destination.Lat) {
+		return UTM{}, fmt.Errorf("polar destination not covered by utm (use ll.Destination() and ll.ToUPS()), destination = %s", destination)
+	}
+
+	return destination.ToUTM(), nil
+}
+
+/*
+Destination solves the direct geodesic problem (WGS84 ellipsoid) from the south-west corner of the
+MGRS cell and returns the destination in MGRS (zone of the destination, accuracy of the start point,
+a 100k square reference without digits returns the 100k square of the destination).
+azimuth holds the azimuth at the start point in degrees (clockwise from true north, not grid north).
+distance holds the distance in meters.
+*/
+func (mgrs MGRS) Destination(azimuth, distance float64) (MGRS, error) {
+
+	ll, accuracy, err := mgrs.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at mgrs.ToLL(), mgrs = %s", err, mgrs)
+	}
+	destination, _, err := ll.Destination(azimuth, distance)
+	if err != nil {
+		return "", err
+	}
+
+	if accuracy == 0 {
+		// keep the number of digits (none), the truncated 10 km digits are cut off
+		target, err := destination.ToMGRS(10000)
+		if err != nil {
+			return "", err
+		}
+		return target[:len(target)-2], nil
+	}
+
+	return destination.ToMGRS(accuracy)
+}
+
+/*
+MilsToDegrees converts an angle in NATO mils (6400 per full circle) to degrees.
+*/
+func MilsToDegrees(mils float64) float64 {
+
+	return mils * 360 / 6400
+}
+
+/*
+DegreesToMils converts an angle in degrees to NATO mils (6400 per full circle).
+*/
+func DegreesToMils(degrees float64) float64 {
+
+	return degrees * 6400 / 360
+}
+
+/*
+azimuth360 reduces an azimuth to the range 0-360 degrees.
+*/
+func azimuth360(azimuth float64) float64 {
+
+	azimuth = math.Mod(azimuth, 360)
+	if azimuth < 0 {
+		azimuth += 360
+	}
+	if azimuth >= 360 {
+		azimuth = 0
+	}
+
+	return azimuth
+}
+
+/*
+newGeodesic returns the geodesic parameters of the ellipsoid.
+*/
+func newGeodesic(ellipsoid Ellipsoid) geodesic {
+
+	g := geodesic{a: ellipsoid.A, f: ellipsoid.F()}
+	g.f1 = 1 - g.f
+	e2 := g.f * (2 - g.f)
+	g.ep2 = e2 / (g.f1 * g.f1)
+	g.n = g.f / (2 - g.f)
+	g.b = g.a * g.f1
+	g.etol2 = 0.1 * geodesicTol2 / math.Sqrt(math.Max(0.001, math.Abs(g.f))*math.Min(1, 1-g.f/2)/2)
+
+	// A3 coefficients (polynomials in n)
+	a3coeff := []float64{
+		-3, 128,
+		-2, -3, 64,
+		-1, -3, -1, 16,
+		3, -1, -2, 8,
+		1, -1, 2,
+		1, 1,
+	}
+	o, k := 0, 0
+	for j := geodesicOrder - 1; j >= 0; j-- {
+		m := minInt(geodesicOrder-j-1, j)
+		g.a3x[k] = polyval(m, a3coeff[o:], g.n) / a3coeff[o+m+1]
+		k++
+		o += m + 2
+	}
+
+	// C3 coefficients (polynomials in n)
+	c3coeff := []float64{
+		3, 128,
+		2, 5, 128,
+		-1, 3, 3, 64,
+		-1, 0, 1, 8,
+		-1, 1, 4,
+		5, 256,
+		1, 3, 128,
+		-3, -2, 3, 64,
+		1, -3, 2, 32,
+		7, 512,
+		-10, 9, 384,
+		5, -9, 5, 192,
+		7, 512,
+		-14, 7, 512,
+		21, 2560,
+	}
+	o, k = 0, 0
+	for l := 1; l < geodesicOrder; l++ {
+		for j := geodesicOrder - 1; j >= l; j-- {
+			m := minInt(geodesicOrder-j-1, j)
+			g.c3x[k] = polyval(m, c3coeff[o:], g.n) / c3coeff[o+m+1]
+			k++
+			o += m + 2
+		}
+	}
+
+	return g
+}
+
+/*
+inverse solves the inverse geodesic problem and returns the distance and the forward azimuths
+at both points (degrees, -180 to 180).
+*/
+func (g geodesic) inverse(lat1, lon1, lat2, lon2 float64) (float64, float64, float64) {
+
+	// longitude difference, reduced to [-180, 180], with the rounding error of the difference
+	lon12, lon12s := angDiff(lon1, lon2)
+	lonsign := 1.0
+	if lon12 < 0 {
+		lonsign = -1
+	}
+	lon12 = lonsign * angRound(lon12)
+	lon12s = angRound((180 - lon12) - lonsign*lon12s)
+	lam12 := degToRad(lon12)
+	var slam12, clam12 float64
+	if lon12 > 90 {
+		slam12, clam12 = sincosd(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = sincosd(lon12)
+	}
+
+	// make lat1 <= -|lat2| (swap points and change signs, undone at the end)
+	lat1 = angRound(lat1)
+	lat2 = angRound(lat2)
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) {
+		swapp = -1
+		lonsign *= -1
+		lat1, lat2 = lat2, lat1
+	}
+	latsign := -1.0
+	if lat1 < 0 {
+		latsign = 1
+	}
+	lat1 *= latsign
+	lat2 *= latsign
+
+	// reduced latitudes
+	sbet1, cbet1 := sincosd(lat1)
+	sbet1 *= g.f1
+	sbet1, cbet1 = norm2(sbet1, cbet1)
+	cbet1 = math.Max(geodesicTiny, cbet1)
+	sbet2, cbet2 := sincosd(lat2)
+	sbet2 *= g.f1
+	sbet2, cbet2 = norm2(sbet2, cbet2)
+	cbet2 = math.Max(geodesicTiny, cbet2)
+
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			sbet2 = math.Copysign(sbet1, sbet2)
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+
+	dn1 := math.Sqrt(1 + g.ep2*sbet1*sbet1)
+	dn2 := math.Sqrt(1 + g.ep2*sbet2*sbet2)
+
+	var c1a, c2a [geodesicOrder + 1]float64
+	var c3a [geodesicOrder]float64
+
+	var s12x, m12x, sig12, salp1, calp1, salp2, calp2 float64
+
+	// meridional geodesic (or start point at a pole)
+	meridian := lat1 == -90 || slam12 == 0
+	if meridian {
+		calp1, salp1 = clam12, slam12
+		calp2, salp2 = 1, 0
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+		sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+		s12x, m12x = g.lengths(g.n, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, &c1a, &c2a)
+		if sig12 < 1 || m12x >= 0 {
+			if sig12 < 3*geodesicTiny || (sig12 < geodesicTol0 && (s12x < 0 || m12x < 0)) {
+				sig12, m12x, s12x = 0, 0, 0
+			}
+			s12x *= g.b
+		} else {
+			meridian = false // conjugate point on the meridian, treat as general case
+		}
+	}
+
+	switch {
+	case meridian:
+		// solved above
+	case sbet1 == 0 && (g.f <= 0 || lon12s >= g.f*180):
+		// equatorial geodesic
+		calp1, calp2 = 0, 0
+		salp1, salp2 = 1, 1
+		s12x = g.a * lam12
+	default:
+		var dnm float64
+		sig12, salp1, calp1, salp2, calp2, dnm = g.inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12)
+		if sig12 >= 0 {
+			// short line, solved by inverseStart
+			s12x = sig12 * g.b * dnm
+			break
+		}
+
+		// Newton iteration on alp1, bracketed by bisection
+		var ssig1, csig1, ssig2, csig2, eps float64
+		numit := 0
+		tripn, tripb := false, false
+		salp1a, calp1a := geodesicTiny, 1.0
+		salp1b, calp1b := geodesicTiny, -1.0
+		for ; numit < geodesicIt2; numit++ {
+			var v, dv float64
+			v, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dv = g.lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2,
+				salp1, calp1, slam12, clam12, numit < geodesicIt1, &c1a, &c2a, &c3a)
+			tolerance := geodesicTol0
+			if tripn {
+				tolerance *= 8
+			}
+			if tripb || !(math.Abs(v) >= tolerance) {
+				break
+			}
+			// update the bracket
+			if v > 0 && (numit > geodesicIt1 || calp1/salp1 > calp1b/salp1b) {
+				salp1b, calp1b = salp1, calp1
+			} else if v < 0 && (numit > geodesicIt1 || calp1/salp1 < calp1a/salp1a) {
+				salp1a, calp1a = salp1, calp1
+			}
+			if numit < geodesicIt1-1 && dv > 0 {
+				dalp1 := -v / dv
+				if math.Abs(dalp1) < math.Pi {
+					sdalp1, cdalp1 := math.Sin(dalp1), math.Cos(dalp1)
+					nsalp1 := salp1*cdalp1 + calp1*sdalp1
+					if nsalp1 > 0 {
+						calp1 = calp1*cdalp1 - salp1*sdalp1
+						salp1 = nsalp1
+						salp1, calp1 = norm2(salp1, calp1)
+						tripn = math.Abs(v) <= 16*geodesicTol0
+						continue
+					}
+				}
+			}
+			// bisection
+			salp1 = (salp1a + salp1b) / 2
+			calp1 = (calp1a + calp1b) / 2
+			salp1, calp1 = norm2(salp1, calp1)
+			tripn = false
+			tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < geodesicTol0 ||
+				math.Abs(salp1-salp1b)+(calp1-calp1b) < geodesicTol0
+		}
+		s12x, _ = g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, &c1a, &c2a)
+		s12x *= g.b
+	}
+
+	// undo the swap and the sign changes
+	if swapp < 0 {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+	}
+	salp1 *= swapp * lonsign
+	calp1 *= swapp * latsign
+	salp2 *= swapp * lonsign
+	calp2 *= swapp * latsign
+
+	return 0 + s12x, atan2d(salp1, calp1), atan2d(salp2, calp2)
+}
+
+/*
+direct solves the direct geodesic problem and returns latitude, longitude (-180 to 180) and the forward
+azimuth at the destination (degrees, -180 to 180).
+*/
+func (g geodesic) direct(lat1, lon1, azi1, s12 float64) (float64, float64, float64) {
+
+	// geodesic line through the start point
+	salp1, calp1 := sincosd(angRound(azi1))
+	sbet1, cbet1 := sincosd(angRound(lat1))
+	sbet1 *= g.f1
+	sbet1, cbet1 = norm2(sbet1, cbet1)
+	cbet1 = math.Max(geodesicTiny, cbet1)
+
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1, somg1 := sbet1, salp0*sbet1
+	csig1 := 1.0
+	if sbet1 != 0 || calp1 != 0 {
+		csig1 = cbet1 * calp1
+	}
+	comg1 := csig1
+	ssig1, csig1 = norm2(ssig1, csig1)
+
+	k2 := calp0 * calp0 * g.ep2
+	eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+
+	var c1a, c1pa [geodesicOrder + 1]float64
+	var c3a [geodesicOrder]float64
+	a1m1 := a1m1f(eps)
+	c1f(eps, &c1a)
+	c1pf(eps, &c1pa)
+	g.c3f(eps, &c3a)
+	b11 := sinCosSeries(true, ssig1, csig1, c1a[:])
+	s, c := math.Sin(b11), math.Cos(b11)
+	stau1 := ssig1*c + csig1*s
+	ctau1 := csig1*c - ssig1*s
+	a3c := -g.f * salp0 * g.a3f(eps)
+	b31 := sinCosSeries(true, ssig1, csig1, c3a[:])
+
+	// arc length on the auxiliary sphere
+	tau12 := s12 / (g.b * (1 + a1m1))
+	s, c = math.Sin(tau12), math.Cos(tau12)
+	b12 := -sinCosSeries(true, stau1*c+ctau1*s, ctau1*c-stau1*s, c1pa[:])
+	sig12 := tau12 - (b12 - b11)
+	ssig12, csig12 := math.Sin(sig12), math.Cos(sig12)
+	if math.Abs(g.f) > 0.01 {
+		// one Newton step for strongly flattened ellipsoids
+		ssig2 := ssig1*csig12 + csig1*ssig12
+		csig2 := csig1*csig12 - ssig1*ssig12
+		b12 = sinCosSeries(true, ssig2, csig2, c1a[:])
+		serr := (1+a1m1)*(sig12+(b12-b11)) - s12/g.b
+		sig12 -= serr / math.Sqrt(1+k2*ssig2*ssig2)
+		ssig12, csig12 = math.Sin(sig12), math.Cos(sig12)
+	}
+
+	// end point
+	ssig2 := ssig1*csig12 + csig1*ssig12
+	csig2 := csig1*csig12 - ssig1*ssig12
+	sbet2 := calp0 * ssig2
+	cbet2 := math.Hypot(salp0, calp0*csig2)
+	if cbet2 == 0 {
+		cbet2, csig2 = geodesicTiny, geodesicTiny // end point at a pole
+	}
+	salp2, calp2 := salp0, calp0*csig2
+
+	somg2, comg2 := salp0*ssig2, csig2
+	omg12 := math.Atan2(somg2*comg1-comg2*somg1, comg2*comg1+somg2*somg1)
+	lam12 := omg12 + a3c*(sig12+(sinCosSeries(true, ssig2, csig2, c3a[:])-b31))
+	lon2 := angNormalize(angNormalize(lon1) + angNormalize(radToDeg(lam12)))
+
+	return atan2d(sbet2, g.f1*cbet2), lon2, atan2d(salp2, calp2)
+}
+
+/*
+inverseStart returns a starting value for alp1 (Newton iteration) or the complete solution for short lines
+(sig12 >= 0).
+*/
+func (g geodesic) inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12 float64) (float64, float64, float64, float64, float64, float64) {
+
+	sig12 := -1.0
+	var salp2, calp2, dnm float64
+
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+	var somg12, comg12 float64
+	if shortline {
+		sbetm2 := (sbet1 + sbet2) * (sbet1 + sbet2)
+		sbetm2 /= sbetm2 + (cbet1+cbet2)*(cbet1+cbet2)
+		dnm = math.Sqrt(1 + g.ep2*sbetm2)
+		omg12 := lam12 / (g.f1 * dnm)
+		somg12, comg12 = math.Sin(omg12), math.Cos(omg12)
+	} else {
+		somg12, comg12 = slam12, clam12
+	}
+
+	salp1 := cbet2 * somg12
+	var calp1 float64
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*somg12*somg12/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+	}
+
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	switch {
+	case shortline && ssig12 < g.etol2:
+		// really short line
+		salp2 = cbet1 * somg12
+		if comg12 >= 0 {
+			calp2 = sbet12 - cbet1*sbet2*somg12*somg12/(1+comg12)
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+		salp2, calp2 = norm2(salp2, calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	case math.Abs(g.n) >= 0.1 || csig12 >= 0 || ssig12 >= 6*math.Abs(g.n)*math.Pi*cbet1*cbet1:
+		// nothing to do, zeroth order spherical approximation is fine
+	default:
+		// nearly antipodal points, scale to the astroid problem
+		lam12x := math.Atan2(-slam12, -clam12)
+		k2 := sbet1 * sbet1 * g.ep2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		lamscale := g.f * cbet1 * g.a3f(eps) * math.Pi
+		betscale := lamscale * cbet1
+		x := lam12x / lamscale
+		y := sbet12a / betscale
+		if y > -geodesicTol1 && x > -1-geodesicXthresh {
+			salp1 = math.Min(1, -x)
+			calp1 = -math.Sqrt(1 - salp1*salp1)
+		} else {
+			k := astroid(x, y)
+			omg12a := lamscale * (-x * k / (1 + k))
+			somg12, comg12 = math.Sin(omg12a), -math.Cos(omg12a)
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+		}
+	}
+
+	if salp1 > 0 {
+		salp1, calp1 = norm2(salp1, calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+
+	return sig12, salp1, calp1, salp2, calp2, dnm
+}
+
+/*
+lambda12 returns the longitude difference on the auxiliary sphere for the given alp1 (and its derivative
+if diffp is set).
+*/
+func (g geodesic) lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64, diffp bool,
+	c1a, c2a *[geodesicOrder + 1]float64, c3a *[geodesicOrder]float64) (float64, float64, float64, float64, float64, float64, float64, float64, float64, float64) {
+
+	if sbet1 == 0 && calp1 == 0 {
+		calp1 = -geodesicTiny // break degeneracy of equatorial line
+	}
+
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1, somg1 := sbet1, salp0*sbet1
+	csig1 := calp1 * cbet1
+	comg1 := csig1
+	ssig1, csig1 = norm2(ssig1, csig1)
+
+	salp2 := salp1
+	if cbet2 != cbet1 {
+		salp2 = salp0 / cbet2
+	}
+	calp2 := math.Abs(calp1)
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		var t float64
+		if cbet1 < -sbet1 {
+			t = (cbet2 - cbet1) * (cbet1 + cbet2)
+		} else {
+			t = (sbet1 - sbet2) * (sbet1 + sbet2)
+		}
+		calp2 = math.Sqrt(calp1*cbet1*calp1*cbet1+t) / cbet2
+	}
+
+	ssig2, somg2 := sbet2, salp0*sbet2
+	csig2 := calp2 * cbet2
+	comg2 := csig2
+	ssig2, csig2 = norm2(ssig2, csig2)
+
+	sig12 := math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := calp0 * calp0 * g.ep2
+	eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	g.c3f(eps, c3a)
+	b312 := sinCosSeries(true, ssig2, csig2, c3a[:]) - sinCosSeries(true, ssig1, csig1, c3a[:])
+	domg12 := -g.f * g.a3f(eps) * salp0 * (sig12 + b312)
+	lam12 := eta + domg12
+
+	dlam12 := math.NaN()
+	if diffp {
+		if calp2 == 0 {
+			dlam12 = -2 * g.f1 * dn1 / sbet1
+		} else {
+			_, dlam12 = g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, c1a, c2a)
+			dlam12 *= g.f1 / (calp2 * cbet2)
+		}
+	}
+
+	return lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dlam12
+}
+
+/*
+lengths returns the distance and the reduced length (both divided by b) along the geodesic.
+*/
+func (g geodesic) lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64,
+	c1a, c2a *[geodesicOrder + 1]float64) (float64, float64) {
+
+	a1 := a1m1f(eps)
+	c1f(eps, c1a)
+	a2 := a2m1f(eps)
+	c2f(eps, c2a)
+	m0x := a1 - a2
+	a1++
+	a2++
+
+	b1 := sinCosSeries(true, ssig2, csig2, c1a[:]) - sinCosSeries(true, ssig1, csig1, c1a[:])
+	s12b := a1 * (sig12 + b1)
+	b2 := sinCosSeries(true, ssig2, csig2, c2a[:]) - sinCosSeries(true, ssig1, csig1, c2a[:])
+	j12 := m0x*sig12 + (a1*b1 - a2*b2)
+	m12b := dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*j12
+
+	return s12b, m12b
+}
+
+/*
+a3f evaluates the A3 series.
+*/
+func (g geodesic) a3f(eps float64) float64 {
+
+	return polyval(geodesicOrder-1, g.a3x[:], eps)
+}
+
+/*
+c3f evaluates the C3 coefficients (c[1] to c[5]).
+*/
+func (g geodesic) c3f(eps float64, c *[geodesicOrder]float64) {
+
+	mult := 1.0
+	o := 0
+	for l := 1; l < geodesicOrder; l++ {
+		m := geodesicOrder - l - 1
+		mult *= eps
+		c[l] = mult * polyval(m, g.c3x[o:], eps)
+		o += m + 1
+	}
+}
+
+/*
+a1m1f evaluates the A1 series minus one.
+*/
+func a1m1f(eps float64) float64 {
+
+	coeff := []float64{1, 4, 64, 0, 256}
+	m := geodesicOrder / 2
+	t := polyval(m, coeff, eps*eps) / coeff[m+1]
+
+	return (t + eps) / (1 - eps)
+}
+
+/*
+c1f evaluates the C1 coefficients (c[1] to c[6]).
+*/
+func c1f(eps float64, c *[geodesicOrder + 1]float64) {
+
+	coeff := []float64{
+		-1, 6, -16, 32,
+		-9, 64, -128, 2048,
+		9, -16, 768,
+		3, -5, 512,
+		-7, 1280,
+		-7, 2048,
+	}
+	seriesCoefficients(eps, coeff, c)
+}
+
+/*
+c1pf evaluates the C1' coefficients (c[1] to c[6], reverted C1 series).
+*/
+func c1pf(eps float64, c *[geodesicOrder + 1]float64) {
+
+	coeff := []float64{
+		205, -432, 768, 1536,
+		4005, -4736, 3840, 12288,
+		-225, 116, 384,
+		-7173, 2695, 7680,
+		3467, 7680,
+		38081, 61440,
+	}
+	seriesCoefficients(eps, coeff, c)
+}
+
+/*
+a2m1f evaluates the A2 series minus one.
+*/
+func a2m1f(eps float64) float64 {
+
+	coeff := []float64{-11, -28, -192, 0, 256}
+	m := geodesicOrder / 2
+	t := polyval(m, coeff, eps*eps) / coeff[m+1]
+
+	return (t - eps) / (1 + eps)
+}
+
+/*
+c2f evaluates the C2 coefficients (c[1] to c[6]).
+*/
+func c2f(eps float64, c *[geodesicOrder + 1]float64) {
+
+	coeff := []float64{
+		1, 2, 16, 32,
+		35, 64, 384, 2048,
+		15, 80, 768,
+		7, 35, 512,
+		63, 1280,
+		77, 2048,
+	}
+	seriesCoefficients(eps, coeff, c)
+}
+
+/*
+seriesCoefficients evaluates the coefficients c[l] = eps^l * polynomial(eps^2) of the C1, C1' and C2 series.
+*/
+func seriesCoefficients(eps float64, coeff []float64, c *[geodesicOrder + 1]float64) {
+
+	eps2 := eps * eps
+	d := eps
+	o := 0
+	for l := 1; l <= geodesicOrder; l++ {
+		m := (geodesicOrder - l) / 2
+		c[l] = d * polyval(m, coeff[o:], eps2) / coeff[o+m+1]
+		o += m + 2
+		d *= eps
+	}
+}
+
+/*
+sinCosSeries evaluates sum(c[l] * sin(2*l*x)) (sinp) or sum(c[l] * cos((2*l+1)*x)) with Clenshaw summation.
+*/
+func sinCosSeries(sinp bool, sinx, cosx float64, c []float64) float64 {
+
+	k := len(c)
+	n := k
+	if sinp {
+		n--
+	}
+	ar := 2 * (cosx - sinx) * (cosx + sinx)
+	y0, y1 := 0.0, 0.0
+	if n&1 == 1 {
+		k--
+		y0 = c[k]
+	}
+	for n /= 2; n > 0; n-- {
+		k--
+		y1 = ar*y0 - y1 + c[k]
+		k--
+		y0 = ar*y1 - y0 + c[k]
+	}
+	if sinp {
+		return 2 * sinx * cosx * y0
+	}
+
+	return cosx * (y0 - y1)
+}
+
+/*
+astroid solves k^4 + 2*k^3 - (x^2 + y^2 - 1)*k^2 - 2*y^2*k - y^2 = 0 for the positive root k.
+*/
+func astroid(x, y float64) float64 {
+
+	p := x * x
+	q := y * y
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+
+	s := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := s * (s + 2*r3)
+	u := r
+	if disc >= 0 {
+		t3 := s + r3
+		if t3 < 0 {
+			t3 -= math.Sqrt(disc)
+		} else {
+			t3 += math.Sqrt(disc)
+		}
+		t := math.Cbrt(t3)
+		if t != 0 {
+			u += t + r2/t
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(s + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+	v := math.Sqrt(u*u + q)
+	var uv float64
+	if u < 0 {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+	w := (uv - q) / (2 * v)
+
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
+
+/*
+polyval evaluates the polynomial p[0]*x^n + p[1]*x^(n-1) + ... + p[n] (Horner).
+*/
+func polyval(n int, p []float64, x float64) float64 {
+
+	if n < 0 {
+		return 0
+	}
+	y := p[0]
+	for i := 1; i <= n; i++ {
+		y = y*x + p[i]
+	}
+
+	return y
+}
+
+/*
+sincosd returns sine and cosine of an angle in degrees (exact for multiples of 90 degrees).
+*/
+func sincosd(x float64) (float64, float64) {
+
+	r := math.Mod(x, 360)
+	q := 0
+	if !math.IsNaN(r) {
+		q = int(math.RoundToEven(r / 90))
+	}
+	r -= 90 * float64(q)
+	r = degToRad(r)
+	s, c := math.Sin(r), math.Cos(r)
+	switch (q%4 + 4) % 4 {
+	case 1:
+		s, c = c, -s
+	case 2:
+		s, c = -s, -c
+	case 3:
+		s, c = -c, s
+	}
+	c += 0 // no negative zero
+	if x == 0 {
+		s = x
+	}
+
+	return s, c
+}
+
+/*
+atan2d returns atan2(y, x) in degrees (exact for multiples of 90 degrees).
+*/
+func atan2d(y, x float64) float64 {
+
+	q := 0
+	if math.Abs(y) > math.Abs(x) {
+		q = 2
+		x, y = y, x
+	}
+	if x < 0 {
+		q++
+		x = -x
+	}
+	ang := radToDeg(math.Atan2(y, x))
+	switch q {
+	case 1:
+		ang = math.Copysign(180, y) - ang
+	case 2:
+		ang = 90 - ang
+	case 3:
+		ang = -90 + ang
+	}
+
+	return ang
+}
+
+/*
+angRound rounds tiny angles (less than 1/16 degree) to avoid underflow.
+*/
+func angRound(x float64) float64 {
+
+	const z = 1.0 / 16
+	y := math.Abs(x)
+	if y < z {
+		y = z - (z - y)
+	}
+
+	return math.Copysign(y, x)
+}
+
+/*
+angNormalize reduces an angle to the range (-180, 180] degrees.
+*/
+func angNormalize(x float64) float64 {
+
+	y := math.Remainder(x, 360)
+	if y == -180 {
+		return 180
+	}
+
+	return y
+}
+
+/*
+angDiff returns the exact difference lon2 - lon1 reduced to [-180, 180] and its rounding error.
+*/
+func angDiff(x, y float64) (float64, float64) {
+
+	d, t := sumError(math.Remainder(-x, 360), math.Remainder(y, 360))
+	d, t2 := sumError(math.Remainder(d, 360), t)
+	if d == 0 || math.Abs(d) == 180 {
+		if t2 == 0 {
+			d = math.Copysign(d, y-x)
+		} else {
+			d = math.Copysign(d, -t2)
+		}
+	}
+
+	return d, t2
+}
+
+/*
+sumError returns the sum of u and v and the rounding error of the sum (error-free transformation).
+*/
+func sumError(u, v float64) (float64, float64) {
+
+	s := u + v
+	up := s - v
+	vpp := s - up
+	up -= u
+	vpp -= v
+	if s == 0 {
+		return s, s
+	}
+
+	return s, 0 - (up + vpp)
+}
+
+/*
+norm2 normalizes the vector (x, y) to unit length.
+*/
+func norm2(x, y float64) (float64, float64) {
+
+	r := math.Hypot(x, y)
+
+	return x / r, y / r
+}
+
+/*
+minInt returns the smaller of two integers.
+*/
+func minInt(a, b int) int {
+
+	if a < b {
+		return a
+	}
+
+	return b
+}
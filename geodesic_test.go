@@ -0,0 +1,241 @@
+/*
+Purpose:
+- Geodesic distance and azimuths between two positions on the ellipsoid (inverse geodesic problem)
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"testing"
+)
+
+func TestLL_DistanceTo(t *testing.T) {
+
+	var tests = []struct {
+		from     LL       // in
+		to       LL       // in
+		geodesic Geodesic // out
+		err      error    // out
+	}{
+		// positive tests (reference values GeographicLib)
+		{LL{Lat: 40.6, Lon: -73.8}, LL{Lat: 51.6, Lon: -0.5}, Geodesic{Distance: 5551759.400319, ForwardAzimuth: 51.198883, BackAzimuth: 287.821777}, nil},
+		{LL{Lat: -41.32, Lon: 174.81}, LL{Lat: 40.96, Lon: -5.50}, Geodesic{Distance: 19959679.267354, ForwardAzimuth: 161.067670, BackAzimuth: 198.825195}, nil},
+		{LL{Lat: -30, Lon: 0}, LL{Lat: 29.9, Lon: 179.8}, Geodesic{Distance: 19989832.827610, ForwardAzimuth: 161.890525, BackAzimuth: 198.090737}, nil}, // nearly antipodal
+		{LL{Lat: 52.52, Lon: 13.405}, LL{Lat: 48.137, Lon: 11.575}, Geodesic{Distance: 504612.741844, ForwardAzimuth: 195.673207, BackAzimuth: 14.263542}, nil},
+		{LL{Lat: 0, Lon: 0}, LL{Lat: 0, Lon: 90}, Geodesic{Distance: 10018754.171395, ForwardAzimuth: 90, BackAzimuth: 270}, nil},  // equator
+		{LL{Lat: 0, Lon: 0}, LL{Lat: 0, Lon: 180}, Geodesic{Distance: 20003931.458625, ForwardAzimuth: 0, BackAzimuth: 0}, nil},    // antipodal, over the pole
+		{LL{Lat: 90, Lon: 0}, LL{Lat: -90, Lon: 0}, Geodesic{Distance: 20003931.458625, ForwardAzimuth: 180, BackAzimuth: 0}, nil}, // pole to pole
+		{LL{Lat: 10, Lon: 20}, LL{Lat: 10, Lon: 20}, Geodesic{Distance: 0, ForwardAzimuth: 180, BackAzimuth: 0}, nil},
+		// negative tests
+		{LL{Lat: 91, Lon: 0}, LL{Lat: 0, Lon: 0}, Geodesic{}, fmt.Errorf("invalid latitude, lat = 91")},
+		{LL{Lat: 0, Lon: 0}, LL{Lat: 0, Lon: math.NaN()}, Geodesic{}, fmt.Errorf("invalid longitude, lon = NaN")},
+	}
+
+	for _, test := range tests {
+		geodesic, err := test.from.DistanceTo(test.to)
+		function := fmt.Sprintf("%#v.DistanceTo(%#v)", test.from, test.to)
+		got := fmt.Sprintf("%.6f %.6f %.6f %v", geodesic.Distance, geodesic.ForwardAzimuth, geodesic.BackAzimuth, err)
+		want := fmt.Sprintf("%.6f %.6f %.6f %v", test.geodesic.Distance, test.geodesic.ForwardAzimuth, test.geodesic.BackAzimuth, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_DistanceToEllipsoid(t *testing.T) {
+
+	geodesic, err := LL{Lat: 40.6, Lon: -73.8}.DistanceToEllipsoid(LL{Lat: 51.6, Lon: -0.5}, EllipsoidBessel1841)
+	got := fmt.Sprintf("%s %v", geodesic, err)
+	want := "5551089.431 51.198794 287.821656 <nil>"
+	if got != want {
+		t.Errorf("\nLL.DistanceToEllipsoid(Bessel1841) -> %s != %s\n", got, want)
+	}
+}
+
+func TestUTM_DistanceTo(t *testing.T) {
+
+	var tests = []struct {
+		from     UTM    // in
+		to       UTM    // in
+		geodesic string // out
+	}{
+		{UTM{ZoneNumber: 33, ZoneLetter: 'U', Easting: 391779, Northing: 5820072}, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 691567, Northing: 5334734}, "504612.907 195.673209 14.263544"},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 500000, Northing: 5000000}, UTM{ZoneNumber: 33, ZoneLetter: 'U', Easting: 500000, Northing: 5000000}, "471707.904 87.872035 272.127965"},
+	}
+
+	for _, test := range tests {
+		geodesic, err := test.from.DistanceTo(test.to)
+		function := fmt.Sprintf("%s.DistanceTo(%s)", test.from, test.to)
+		got := fmt.Sprintf("%s %v", geodesic, err)
+		want := fmt.Sprintf("%s %v", test.geodesic, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMGRS_DistanceTo(t *testing.T) {
+
+	var tests = []struct {
+		from     MGRS   // in
+		to       MGRS   // in
+		geodesic string // out
+		err      error  // out
+	}{
+		// positive tests
+		{"33UUU9177920072", "32UPU9156734734", "504612.907 195.673209 14.263544", nil},
+		{"32ULC", "32UMC", "100011.353 87.750883 268.874731", nil}, // south-west corners of the 100k squares
+		// negative tests
+		{"32UXX", "32ULC", "0.000 0.000000 0.000000", fmt.Errorf("error <error <invalid 100k column letter 'X' for zone 32U at position 4, mgrs = 32UXX> at mgrs.ToUTM()> at mgrs.ToLL(), mgrs = 32UXX")},
+	}
+
+	for _, test := range tests {
+		geodesic, err := test.from.DistanceTo(test.to)
+		function := fmt.Sprintf("%s.DistanceTo(%s)", test.from, test.to)
+		got := fmt.Sprintf("%s %v", geodesic, err)
+		want := fmt.Sprintf("%s %v", test.geodesic, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_Destination(t *testing.T) {
+
+	var tests = []struct {
+		from     LL      // in
+		azimuth  float64 // in
+		distance float64 // in
+		to       LL      // out
+		azimuth2 float64 // out
+		err      error   // out
+	}{
+		// positive tests (reference values GeographicLib)
+		{LL{Lat: 40, Lon: 0}, 30, 10000e3, LL{Lat: 41.79331020506, Lon: 137.84490004377}, 149.09016931807, nil},
+		{LL{Lat: -32.06, Lon: 115.74}, 225, 20000e3, LL{Lat: 32.11195529143, Lon: -63.95925278364}, 314.96756469377, nil},
+		{LL{Lat: 40.6, Lon: -73.8}, 51.198882845579824, 5551759.400319, LL{Lat: 51.6, Lon: -0.5}, 107.82177673552, nil},
+		{LL{Lat: 0, Lon: 0}, 90, 10018754.171394622, LL{Lat: 0, Lon: 90}, 90, nil},
+		{LL{Lat: 52, Lon: 5}, -90, 1000e3, LL{Lat: 51.11064675119, Lon: -9.37150928566}, 258.72088379490, nil},
+		{LL{Lat: 10, Lon: 20}, 0, 0, LL{Lat: 10, Lon: 20}, 0, nil},
+		{LL{Lat: 89.9, Lon: 0}, 0, 50000, LL{Lat: 89.65234825, Lon: 180}, 180, nil}, // across the pole
+		// negative tests
+		{LL{Lat: 91, Lon: 0}, 0, 1000, LL{}, 0, fmt.Errorf("invalid latitude, lat = 91")},
+		{LL{Lat: 0, Lon: 0}, math.NaN(), 1000, LL{}, 0, fmt.Errorf("invalid azimuth, azimuth = NaN")},
+		{LL{Lat: 0, Lon: 0}, 0, math.Inf(1), LL{}, 0, fmt.Errorf("invalid distance, distance = +Inf")},
+	}
+
+	for _, test := range tests {
+		to, azimuth2, err := test.from.Destination(test.azimuth, test.distance)
+		function := fmt.Sprintf("%#v.Destination(%v, %v)", test.from, test.azimuth, test.distance)
+		got := fmt.Sprintf("%.8f %.8f %.8f %v", to.Lat, to.Lon, azimuth2, err)
+		want := fmt.Sprintf("%.8f %.8f %.8f %v", test.to.Lat, test.to.Lon, test.azimuth2, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestUTM_Destination(t *testing.T) {
+
+	var tests = []struct {
+		from     UTM     // in
+		azimuth  float64 // in
+		distance float64 // in
+		to       UTM     // out
+		err      error   // out
+	}{
+		// positive tests
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 500000, Northing: 5500000}, 0, 10000, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 500000, Northing: 5509996}, nil},   // scale factor 0.9996 on the central meridian
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 700000, Northing: 5500000}, 90, 100000, UTM{ZoneNumber: 33, ZoneLetter: 'U', Easting: 366555, Northing: 5497036}, nil}, // into the next zone
+		// negative tests
+		{UTM{ZoneNumber: 32, ZoneLetter: 'X', Easting: 500000, Northing: 9300000}, 0, 500000, UTM{}, fmt.Errorf("polar destination not covered by utm (use ll.Destination() and ll.ToUPS()), destination = 88.225107 9.000000")},
+		{UTM{ZoneNumber: 32, ZoneLetter: 'C', Easting: 500000, Northing: 1000000}, 180, 500000, UTM{}, fmt.Errorf("polar destination not covered by utm (use ll.Destination() and ll.ToUPS()), destination = -85.538036 9.000000")},
+	}
+
+	for _, test := range tests {
+		to, err := test.from.Destination(test.azimuth, test.distance)
+		function := fmt.Sprintf("%s.Destination(%v, %v)", test.from, test.azimuth, test.distance)
+		got := fmt.Sprintf("%s %v", to, err)
+		want := fmt.Sprintf("%s %v", test.to, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMGRS_Destination(t *testing.T) {
+
+	var tests = []struct {
+		from     MGRS    // in
+		azimuth  float64 // in
+		distance float64 // in
+		to       MGRS    // out
+		err      error   // out
+	}{
+		// positive tests
+		{"32UMV1234567890", MilsToDegrees(1200), 2400, "32UMV1457668772", nil},
+		{"32UMV12346789", MilsToDegrees(1200), 2400, "32UMV14576877", nil}, // accuracy of the start point
+		{"32UPU9156734734", 90, 50000, "33UTP9516634975", nil},             // into the next zone
+		{"32UMV", 45, 150000, "32UNA", nil},                                // 100k square reference (no digits)
+		{"32UMV", 90, 1000, "32UMU", nil},                                  // south-west corner, grid north differs from true north
+		{"ZAH", 180, 50000, "ZAG", nil},
+		// negative tests
+		{"32UMV123456789", 0, 1000, "", fmt.Errorf("error <error <uneven number of digits (9) at position 14, mgrs = 32UMV123456789> at mgrs.ToUTM()> at mgrs.ToLL(), mgrs = 32UMV123456789")},
+	}
+
+	for _, test := range tests {
+		to, err := test.from.Destination(test.azimuth, test.distance)
+		function := fmt.Sprintf("%s.Destination(%v, %v)", test.from, test.azimuth, test.distance)
+		got := fmt.Sprintf("%s %v", to, err)
+		want := fmt.Sprintf("%s %v", test.to, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMilsToDegrees(t *testing.T) {
+
+	got := fmt.Sprintf("%v %v %v %v", MilsToDegrees(1200), MilsToDegrees(6400), DegreesToMils(90), DegreesToMils(67.5))
+	want := "67.5 360 1600 1200"
+	if got != want {
+		t.Errorf("\nMilsToDegrees() / DegreesToMils() -> %s != %s\n", got, want)
+	}
+}
+
+func ExampleMGRS_DistanceTo() {
+
+	from := MGRS("33UUU9177920072") // Berlin
+	to := MGRS("32UPU9156734734")   // Munich
+	geodesic, err := from.DistanceTo(to)
+	if err != nil {
+		log.Printf("error <%v> at mgrs.DistanceTo()", err)
+		return
+	}
+	fmt.Printf("%.1f km\n", geodesic.Distance/1000)
+	fmt.Printf("%.1f°\n", geodesic.ForwardAzimuth)
+
+	// Output:
+	// 504.6 km
+	// 195.7°
+}
+
+func ExampleMGRS_Destination() {
+
+	// observer report: from 32UMV1234567890, bearing 1200 mils, 2.4 km
+	target, err := MGRS("32UMV1234567890").Destination(MilsToDegrees(1200), 2400)
+	if err != nil {
+		log.Printf("error <%v> at mgrs.Destination()", err)
+		return
+	}
+	fmt.Println(target)
+
+	// Output:
+	// 32UMV1457668772
+}
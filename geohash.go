@@ -0,0 +1,233 @@
+/*
+Purpose:
+- Geohash <-> Lon Lat, MGRS
+
+Description:
+- Geohash encodes a position as base32 string of interleaved longitude/latitude bits
+  (e.g. "u4pruydqqvj" for 57.64911°N 10.40744°E). Each character refines the cell by 5 bits.
+- Decoding returns the cell center and the cell bounding box.
+- Adjacent cells are calculated via the cell center and the cell size (wrap around at the antimeridian).
+
+Remarks:
+- Precision 1 to 12 characters (cell size 5000 km down to 3.7 cm x 1.9 cm).
+- Geohash is case insensitive, the canonical form is lower case.
+
+Links:
+- https://en.wikipedia.org/wiki/Geohash
+- http://geohash.org/
+*/
+
+package coco
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Geohash defines a cell in Geohash notation
+type Geohash string
+
+// geohashBase32 holds the Geohash alphabet (without a, i, l, o)
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// geohashMaxPrecision holds the maximum number of Geohash characters
+const geohashMaxPrecision = 12
+
+/*
+ToGeohash converts Lon Lat to Geohash.
+precision holds the wanted number of characters (1-12).
+*/
+func (ll LL) ToGeohash(precision int) (Geohash, error) {
+
+	if precision < 1 || precision > geohashMaxPrecision {
+		return "", fmt.Errorf("invalid precision (1-%d), precision = %d", geohashMaxPrecision, precision)
+	}
+	if !(ll.Lon >= -180 && ll.Lon <= 180) {
+		return "", fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if !(ll.Lat >= -90 && ll.Lat <= 90) {
+		return "", fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	bbox := BoundingBox{South: -90, West: -180, North: 90, East: 180}
+	isLon := true // bits alternate, starting with longitude
+
+	var sb strings.Builder
+	for sb.Len() < precision {
+		index := 0
+		for bit := 0; bit < 5; bit++ {
+			index <<= 1
+			if isLon {
+				mid := (bbox.West + bbox.East) / 2
+				if ll.Lon >= mid {
+					index |= 1
+					bbox.West = mid
+				} else {
+					bbox.East = mid
+				}
+			} else {
+				mid := (bbox.South + bbox.North) / 2
+				if ll.Lat >= mid {
+					index |= 1
+					bbox.South = mid
+				} else {
+					bbox.North = mid
+				}
+			}
+			isLon = !isLon
+		}
+		sb.WriteByte(geohashBase32[index])
+	}
+
+	return Geohash(sb.String()), nil
+}
+
+/*
+Validate checks the Geohash string (length 1-12, valid base32 characters, case insensitive).
+*/
+func (gh Geohash) Validate() error {
+
+	if len(gh) < 1 || len(gh) > geohashMaxPrecision {
+		return fmt.Errorf("invalid geohash length (1-%d), geohash = %s", geohashMaxPrecision, gh)
+	}
+	for _, char := range strings.ToLower(string(gh)) {
+		if !strings.ContainsRune(geohashBase32, char) {
+			return fmt.Errorf("invalid geohash character %q, geohash = %s", char, gh)
+		}
+	}
+
+	return nil
+}
+
+/*
+Bounds returns the bounding box of the Geohash cell.
+*/
+func (gh Geohash) Bounds() (BoundingBox, error) {
+
+	if err := gh.Validate(); err != nil {
+		return BoundingBox{}, err
+	}
+
+	bbox := BoundingBox{South: -90, West: -180, North: 90, East: 180}
+	isLon := true
+
+	for _, char := range strings.ToLower(string(gh)) {
+		index := strings.IndexRune(geohashBase32, char)
+		for bit := 4; bit >= 0; bit-- {
+			set := index>>uint(bit)&1 == 1
+			if isLon {
+				mid := (bbox.West + bbox.East) / 2
+				if set {
+					bbox.West = mid
+				} else {
+					bbox.East = mid
+				}
+			} else {
+				mid := (bbox.South + bbox.North) / 2
+				if set {
+					bbox.South = mid
+				} else {
+					bbox.North = mid
+				}
+			}
+			isLon = !isLon
+		}
+	}
+
+	return bbox, nil
+}
+
+/*
+ToLL converts Geohash to Lon Lat (center of the cell).
+*/
+func (gh Geohash) ToLL() (LL, error) {
+
+	bbox, err := gh.Bounds()
+	if err != nil {
+		return LL{}, err
+	}
+
+	return bbox.Center(), nil
+}
+
+/*
+Adjacent returns the adjacent Geohash cell (same precision) in the given direction.
+The longitude wraps around at the antimeridian, there is no adjacent cell beyond the poles.
+direction holds the compass direction (e.g. North or SouthWest).
+*/
+func (gh Geohash) Adjacent(direction Direction) (Geohash, error) {
+
+	if direction < North || direction > NorthWest {
+		return "", fmt.Errorf("invalid direction, direction = %v", direction)
+	}
+	bbox, err := gh.Bounds()
+	if err != nil {
+		return "", err
+	}
+
+	latOffset, lonOffset := direction.offset()
+	center := bbox.Center()
+	lat := center.Lat + float64(latOffset)*(bbox.North-bbox.South)
+	lon := center.Lon + float64(lonOffset)*(bbox.East-bbox.West)
+	if lat < -90 || lat > 90 {
+		return "", fmt.Errorf("no adjacent cell beyond the pole, geohash = %s, direction = %s", gh, direction)
+	}
+	if lon < -180 {
+		lon += 360
+	}
+	if lon > 180 {
+		lon -= 360
+	}
+
+	return LL{Lat: lat, Lon: lon}.ToGeohash(len(gh))
+}
+
+/*
+Neighbours returns the adjacent Geohash cells in all eight directions.
+Directions beyond the poles are missing in the result.
+*/
+func (gh Geohash) Neighbours() (map[Direction]Geohash, error) {
+
+	if err := gh.Validate(); err != nil {
+		return nil, err
+	}
+
+	neighbours := map[Direction]Geohash{}
+	for _, direction := range Directions {
+		adjacent, err := gh.Adjacent(direction)
+		if err != nil {
+			continue // beyond the pole
+		}
+		neighbours[direction] = adjacent
+	}
+
+	return neighbours, nil
+}
+
+/*
+ToMGRS converts Geohash (center of the cell) to MGRS/UTMREF.
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+*/
+func (gh Geohash) ToMGRS(accuracy int) (MGRS, error) {
+
+	ll, err := gh.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at gh.ToLL(), geohash = %s", err, gh)
+	}
+
+	return ll.ToMGRS(accuracy)
+}
+
+/*
+ToGeohash converts MGRS/UTMREF (south-west corner of the MGRS cell) to Geohash.
+precision holds the wanted number of characters (1-12).
+*/
+func (mgrs MGRS) ToGeohash(precision int) (Geohash, error) {
+
+	ll, _, err := mgrs.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at mgrs.ToLL(), mgrs = %s", err, mgrs)
+	}
+
+	return ll.ToGeohash(precision)
+}
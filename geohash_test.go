@@ -0,0 +1,198 @@
+/*
+Purpose:
+- Geohash <-> Lon Lat, MGRS
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"testing"
+)
+
+func TestLL_ToGeohash(t *testing.T) {
+
+	var tests = []struct {
+		ll        LL      // in
+		precision int     // in
+		geohash   Geohash // out
+		err       error   // out
+	}{
+		// positive tests
+		{LL{Lat: 57.64911, Lon: 10.40744}, 11, "u4pruydqqvj", nil}, // Wikipedia example
+		{LL{Lat: 51.95, Lon: 7.53}, 9, "u1jre35nt", nil},
+		{LL{Lat: -25.382708, Lon: -49.265506}, 8, "6gkzwgjz", nil},
+		{LL{Lat: 51.95, Lon: 7.53}, 1, "u", nil},
+		{LL{Lat: 90, Lon: 180}, 5, "zzzzz", nil},
+		{LL{Lat: -90, Lon: -180}, 5, "00000", nil},
+		// negative tests
+		{LL{Lat: 51.95, Lon: 7.53}, 0, "", fmt.Errorf("invalid precision (1-12), precision = 0")},
+		{LL{Lat: 51.95, Lon: 7.53}, 13, "", fmt.Errorf("invalid precision (1-12), precision = 13")},
+		{LL{Lat: 91, Lon: 7.53}, 5, "", fmt.Errorf("invalid latitude, lat = 91")},
+		{LL{Lat: math.NaN(), Lon: 7.53}, 5, "", fmt.Errorf("invalid latitude, lat = NaN")},
+		{LL{Lat: 51.95, Lon: math.NaN()}, 5, "", fmt.Errorf("invalid longitude, lon = NaN")},
+	}
+
+	for _, test := range tests {
+		geohash, err := test.ll.ToGeohash(test.precision)
+		function := fmt.Sprintf("%#v.ToGeohash(%d)", test.ll, test.precision)
+		got := fmt.Sprintf("%s %v", geohash, err)
+		want := fmt.Sprintf("%s %v", test.geohash, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestGeohash_Bounds(t *testing.T) {
+
+	var tests = []struct {
+		geohash Geohash     // in
+		bbox    BoundingBox // out
+		ll      LL          // out
+		err     error       // out
+	}{
+		// positive tests
+		{"ezs42", BoundingBox{South: 42.583008, West: -5.625000, North: 42.626953, East: -5.581055}, LL{Lat: 42.604980, Lon: -5.603027}, nil},
+		{"EZS42", BoundingBox{South: 42.583008, West: -5.625000, North: 42.626953, East: -5.581055}, LL{Lat: 42.604980, Lon: -5.603027}, nil},
+		{"u4pruydqqvj", BoundingBox{South: 57.649110, West: 10.407439, North: 57.649111, East: 10.407440}, LL{Lat: 57.649111, Lon: 10.407440}, nil},
+		{"s", BoundingBox{South: 0, West: 0, North: 45, East: 45}, LL{Lat: 22.5, Lon: 22.5}, nil},
+		// negative tests
+		{"u1qa", BoundingBox{}, LL{}, fmt.Errorf("invalid geohash character 'a', geohash = u1qa")},
+		{"", BoundingBox{}, LL{}, fmt.Errorf("invalid geohash length (1-12), geohash = ")},
+		{"u1qcvvywu1qcv", BoundingBox{}, LL{}, fmt.Errorf("invalid geohash length (1-12), geohash = u1qcvvywu1qcv")},
+	}
+
+	for _, test := range tests {
+		bbox, err := test.geohash.Bounds()
+		function := fmt.Sprintf("%s.Bounds()", test.geohash)
+		got := fmt.Sprintf("%s %v", bbox, err)
+		want := fmt.Sprintf("%s %v", test.bbox, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		ll, err := test.geohash.ToLL()
+		function = fmt.Sprintf("%s.ToLL()", test.geohash)
+		got = fmt.Sprintf("%s %v", ll, err)
+		want = fmt.Sprintf("%s %v", test.ll, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestGeohash_Adjacent(t *testing.T) {
+
+	var tests = []struct {
+		geohash   Geohash   // in
+		direction Direction // in
+		adjacent  Geohash   // out
+		err       error     // out
+	}{
+		// positive tests
+		{"u1qcvvyw", North, "u1qcvvyx", nil},
+		{"u1qcvvyw", SouthWest, "u1qcvvym", nil},
+		{"9", West, "8", nil},
+		{"9", NorthWest, "b", nil},
+		{"b", West, "z", nil}, // antimeridian
+		{"z", East, "b", nil}, // antimeridian
+		// negative tests
+		{"b", North, "", fmt.Errorf("no adjacent cell beyond the pole, geohash = b, direction = N")},
+		{"0", South, "", fmt.Errorf("no adjacent cell beyond the pole, geohash = 0, direction = S")},
+		{"9", Direction(8), "", fmt.Errorf("invalid direction, direction = Direction(8)")},
+	}
+
+	for _, test := range tests {
+		adjacent, err := test.geohash.Adjacent(test.direction)
+		function := fmt.Sprintf("%s.Adjacent(%s)", test.geohash, test.direction)
+		got := fmt.Sprintf("%s %v", adjacent, err)
+		want := fmt.Sprintf("%s %v", test.adjacent, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestGeohash_Neighbours(t *testing.T) {
+
+	var tests = []struct {
+		geohash    Geohash // in
+		neighbours string  // out
+		err        error   // out
+	}{
+		// positive tests
+		{"u1qcvvyw", "map[N:u1qcvvyx NE:u1qcvvyz E:u1qcvvyy SE:u1qcvvyv S:u1qcvvyt SW:u1qcvvym W:u1qcvvyq NW:u1qcvvyr]", nil},
+		{"9", "map[N:c NE:f E:d SE:6 S:3 SW:2 W:8 NW:b]", nil},
+		{"b", "map[E:c SE:9 S:8 SW:x W:z]", nil}, // north pole, antimeridian
+		// negative tests
+		{"ezs4a", "map[]", fmt.Errorf("invalid geohash character 'a', geohash = ezs4a")},
+	}
+
+	for _, test := range tests {
+		neighbours, err := test.geohash.Neighbours()
+		function := fmt.Sprintf("%s.Neighbours()", test.geohash)
+		got := fmt.Sprintf("%v %v", neighbours, err)
+		want := fmt.Sprintf("%s %v", test.neighbours, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestGeohash_MGRS(t *testing.T) {
+
+	var tests = []struct {
+		geohash Geohash // in
+		mgrs    MGRS    // out
+		back    Geohash // out
+	}{
+		{"u1jre35ntty", "32ULC9897356497", "u1jre35nt"},
+		{"u4pruydqqvj", "32VNJ8400190517", "u4pruydqq"},
+		{"6gkzwgjzn82", "22JFS7449891542", "6gkzwgjzn"},
+	}
+
+	for _, test := range tests {
+		mgrs, err := test.geohash.ToMGRS(1)
+		function := fmt.Sprintf("%s.ToMGRS(1)", test.geohash)
+		got := fmt.Sprintf("%s %v", mgrs, err)
+		want := fmt.Sprintf("%s %v", test.mgrs, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		back, err := mgrs.ToGeohash(9)
+		function = fmt.Sprintf("%s.ToGeohash(9)", mgrs)
+		got = fmt.Sprintf("%s %v", back, err)
+		want = fmt.Sprintf("%s %v", test.back, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleGeohash_ToMGRS() {
+
+	geohash := Geohash("u1jre35nt")
+	bbox, err := geohash.Bounds()
+	if err != nil {
+		log.Printf("error <%v> at geohash.Bounds()", err)
+		return
+	}
+	mgrs, err := geohash.ToMGRS(1)
+	if err != nil {
+		log.Printf("error <%v> at geohash.ToMGRS()", err)
+		return
+	}
+	fmt.Println(bbox)
+	fmt.Println(mgrs)
+
+	// Output:
+	// 51.949968 7.529969 51.950011 7.530012
+	// 32ULC9897356496
+}
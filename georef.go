@@ -0,0 +1,315 @@
+/*
+Purpose:
+- GEOREF (World Geographic Reference System) <-> Lon Lat, MGRS
+
+Description:
+- GEOREF references (e.g. "GJPJ3217") consist of a 15° tile (longitude letter A-Z, latitude letter A-M),
+  a 1° square (longitude letter A-Q, latitude letter A-Q) and optional minutes
+  (longitude digits followed by latitude digits, 2 digits = 1', 3 digits = 0.1', 4 digits = 0.01').
+- Tiles and squares count from 180°W and 90°S, letters I and O are not used.
+
+Remarks:
+- Precision 2, 4, 8, 10 or 12 characters, case insensitive. Canonical form: upper case without spaces.
+
+Links:
+- https://en.wikipedia.org/wiki/World_Geographic_Reference_System
+*/
+
+package coco
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// GEOREF defines a cell in World Geographic Reference System notation
+type GEOREF string
+
+// GEOREFPrecision defines the GEOREF precision (number of characters)
+type GEOREFPrecision int
+
+// GEOREF precisions
+const (
+	GEOREFTile            GEOREFPrecision = 2  // 15° tile (e.g. "GJ")
+	GEOREFDegree          GEOREFPrecision = 4  // 1° square (e.g. "GJPJ")
+	GEOREFMinute          GEOREFPrecision = 8  // 1' (e.g. "GJPJ3217")
+	GEOREFTenthMinute     GEOREFPrecision = 10 // 0.1' (e.g. "GJPJ325172")
+	GEOREFHundredthMinute GEOREFPrecision = 12 // 0.01' (e.g. "GJPJ32501720")
+)
+
+// georefLetters holds the GEOREF letters (without I and O)
+const georefLetters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// GEOREF unit resolution (0.01' units per degree and per 15° tile)
+const (
+	georefUnits     = 6000
+	georefTileUnits = 15 * georefUnits
+)
+
+/*
+unitSize returns the cell size of the GEOREF precision in 0.01' units, 0 for an invalid precision.
+*/
+func (precision GEOREFPrecision) unitSize() int {
+
+	switch precision {
+	case GEOREFTile:
+		return georefTileUnits
+	case GEOREFDegree:
+		return georefUnits
+	case GEOREFMinute:
+		return 100
+	case GEOREFTenthMinute:
+		return 10
+	case GEOREFHundredthMinute:
+		return 1
+	}
+
+	return 0
+}
+
+/*
+ToGEOREF converts Lon Lat to GEOREF.
+precision holds the wanted precision (GEOREFTile, GEOREFDegree, GEOREFMinute, GEOREFTenthMinute or GEOREFHundredthMinute).
+*/
+func (ll LL) ToGEOREF(precision GEOREFPrecision) (GEOREF, error) {
+
+	if precision.unitSize() == 0 {
+		return "", fmt.Errorf("invalid georef precision (2, 4, 8, 10 or 12 characters), precision = %d", precision)
+	}
+	if !(ll.Lon >= -180 && ll.Lon <= 180) {
+		return "", fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if !(ll.Lat >= -90 && ll.Lat <= 90) {
+		return "", fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	// 0.01' units from 180°W and 90°S
+	lonVal := cellIndex(normalizeLon(ll.Lon)+180, georefUnits)
+	latVal := cellIndex(ll.Lat+90, georefUnits)
+
+	// the north pole is encoded into the cell below
+	if latVal >= 180*georefUnits {
+		latVal = 180*georefUnits - 1
+	}
+
+	var sb strings.Builder
+	sb.WriteByte(georefLetters[lonVal/georefTileUnits])
+	sb.WriteByte(georefLetters[latVal/georefTileUnits])
+	if precision == GEOREFTile {
+		return GEOREF(sb.String()), nil
+	}
+
+	lonVal %= georefTileUnits
+	latVal %= georefTileUnits
+	sb.WriteByte(georefLetters[lonVal/georefUnits])
+	sb.WriteByte(georefLetters[latVal/georefUnits])
+	if precision == GEOREFDegree {
+		return GEOREF(sb.String()), nil
+	}
+
+	// minutes with 2, 3 or 4 digits
+	digits := (int(precision) - 4) / 2
+	size := precision.unitSize()
+	fmt.Fprintf(&sb, "%0*d%0*d", digits, lonVal%georefUnits/size, digits, latVal%georefUnits/size)
+
+	return GEOREF(sb.String()), nil
+}
+
+/*
+ParseGEOREF parses a GEOREF string (e.g. "GJPJ3217", "gj pj 32 17") and returns the canonical form.
+s holds the GEOREF string.
+*/
+func ParseGEOREF(s string) (GEOREF, error) {
+
+	georef := GEOREF(strings.ToUpper(strings.Join(strings.FieldsFunc(s, unicode.IsSpace), "")))
+	if err := georef.Validate(); err != nil {
+		return "", err
+	}
+
+	return georef, nil
+}
+
+/*
+Validate checks the GEOREF string (length, tile and square letters, minutes 00-59, case insensitive).
+*/
+func (georef GEOREF) Validate() error {
+
+	_, _, _, err := georef.units()
+
+	return err
+}
+
+/*
+Precision returns the precision of the GEOREF string (0 for an invalid string).
+*/
+func (georef GEOREF) Precision() GEOREFPrecision {
+
+	_, _, precision, err := georef.units()
+	if err != nil {
+		return 0
+	}
+
+	return precision
+}
+
+/*
+Format returns the GEOREF string grouped by tile, square and minutes (e.g. "GJ PJ 32 17").
+*/
+func (georef GEOREF) Format() (string, error) {
+
+	_, _, precision, err := georef.units()
+	if err != nil {
+		return "", err
+	}
+
+	s := strings.ToUpper(string(georef))
+	groups := []string{s[0:2]}
+	if precision >= GEOREFDegree {
+		groups = append(groups, s[2:4])
+	}
+	if precision >= GEOREFMinute {
+		digits := (len(s) - 4) / 2
+		groups = append(groups, s[4:4+digits], s[4+digits:])
+	}
+
+	return strings.Join(groups, " "), nil
+}
+
+/*
+units parses the GEOREF string and returns the south-west corner in 0.01' units and the precision.
+*/
+func (georef GEOREF) units() (int, int, GEOREFPrecision, error) {
+
+	s := strings.ToUpper(string(georef))
+
+	precision := GEOREFPrecision(len(s))
+	if precision.unitSize() == 0 {
+		return 0, 0, 0, fmt.Errorf("invalid georef length (2, 4, 8, 10 or 12 characters), georef = %s", georef)
+	}
+
+	lonTile := strings.IndexByte(georefLetters, s[0])
+	if lonTile < 0 {
+		return 0, 0, 0, fmt.Errorf("invalid longitude tile (A-Z without I and O), georef = %s", georef)
+	}
+	latTile := strings.IndexByte(georefLetters[:12], s[1])
+	if latTile < 0 {
+		return 0, 0, 0, fmt.Errorf("invalid latitude tile (A-M without I), georef = %s", georef)
+	}
+	lonVal := lonTile * georefTileUnits
+	latVal := latTile * georefTileUnits
+	if precision == GEOREFTile {
+		return lonVal, latVal, precision, nil
+	}
+
+	lonDegree := strings.IndexByte(georefLetters[:15], s[2])
+	if lonDegree < 0 {
+		return 0, 0, 0, fmt.Errorf("invalid longitude degree (A-Q without I and O), georef = %s", georef)
+	}
+	latDegree := strings.IndexByte(georefLetters[:15], s[3])
+	if latDegree < 0 {
+		return 0, 0, 0, fmt.Errorf("invalid latitude degree (A-Q without I and O), georef = %s", georef)
+	}
+	lonVal += lonDegree * georefUnits
+	latVal += latDegree * georefUnits
+	if precision == GEOREFDegree {
+		return lonVal, latVal, precision, nil
+	}
+
+	digits := (len(s) - 4) / 2
+	size := precision.unitSize()
+	lonMinutes, err := georefMinutes(s[4 : 4+digits])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid longitude minutes (00-59), georef = %s", georef)
+	}
+	latMinutes, err := georefMinutes(s[4+digits:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid latitude minutes (00-59), georef = %s", georef)
+	}
+	lonVal += lonMinutes * size
+	latVal += latMinutes * size
+
+	return lonVal, latVal, precision, nil
+}
+
+/*
+georefMinutes returns the value of a minutes digit group (e.g. "325" -> 325, 32.5 minutes).
+digits holds 2, 3 or 4 digits, the first two digits are the full minutes (00-59).
+*/
+func georefMinutes(digits string) (int, error) {
+
+	value := 0
+	for _, char := range digits {
+		if char < '0' || char > '9' {
+			return 0, fmt.Errorf("invalid digit %q", char)
+		}
+		value = value*10 + int(char-'0')
+	}
+	if digits[0:2] > "59" {
+		return 0, fmt.Errorf("minutes out of range (00-59)")
+	}
+
+	return value, nil
+}
+
+/*
+Bounds returns the bounding box of the GEOREF cell.
+*/
+func (georef GEOREF) Bounds() (BoundingBox, error) {
+
+	lonVal, latVal, precision, err := georef.units()
+	if err != nil {
+		return BoundingBox{}, err
+	}
+
+	size := precision.unitSize()
+
+	bbox := BoundingBox{}
+	bbox.West = float64(lonVal)/georefUnits - 180
+	bbox.South = float64(latVal)/georefUnits - 90
+	bbox.East = float64(lonVal+size)/georefUnits - 180
+	bbox.North = float64(latVal+size)/georefUnits - 90
+
+	return bbox, nil
+}
+
+/*
+ToLL converts GEOREF to Lon Lat (center of the cell).
+*/
+func (georef GEOREF) ToLL() (LL, error) {
+
+	bbox, err := georef.Bounds()
+	if err != nil {
+		return LL{}, err
+	}
+
+	return bbox.Center(), nil
+}
+
+/*
+ToMGRS converts GEOREF (center of the cell) to MGRS/UTMREF.
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+*/
+func (georef GEOREF) ToMGRS(accuracy int) (MGRS, error) {
+
+	ll, err := georef.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at georef.ToLL(), georef = %s", err, georef)
+	}
+
+	return ll.ToMGRS(accuracy)
+}
+
+/*
+ToGEOREF converts MGRS/UTMREF (south-west corner of the MGRS cell) to GEOREF.
+precision holds the wanted precision (GEOREFTile, GEOREFDegree, GEOREFMinute, GEOREFTenthMinute or GEOREFHundredthMinute).
+*/
+func (mgrs MGRS) ToGEOREF(precision GEOREFPrecision) (GEOREF, error) {
+
+	ll, _, err := mgrs.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at mgrs.ToLL(), mgrs = %s", err, mgrs)
+	}
+
+	return ll.ToGEOREF(precision)
+}
@@ -0,0 +1,189 @@
+/*
+Purpose:
+- GEOREF (World Geographic Reference System) <-> Lon Lat, MGRS
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"testing"
+)
+
+func TestLL_ToGEOREF(t *testing.T) {
+
+	var tests = []struct {
+		ll        LL              // in
+		precision GEOREFPrecision // in
+		georef    GEOREF          // out
+		err       error           // out
+	}{
+		// positive tests
+		{LL{Lat: 38.29, Lon: -76.46}, GEOREFTile, "GJ", nil},
+		{LL{Lat: 38.29, Lon: -76.46}, GEOREFDegree, "GJPJ", nil},
+		{LL{Lat: 38.29, Lon: -76.46}, GEOREFMinute, "GJPJ3217", nil},
+		{LL{Lat: 38.29, Lon: -76.46}, GEOREFTenthMinute, "GJPJ324174", nil},
+		{LL{Lat: 38.29, Lon: -76.46}, GEOREFHundredthMinute, "GJPJ32401740", nil},
+		{LL{Lat: 51.95, Lon: 7.53}, GEOREFHundredthMinute, "NKHG31805700", nil},
+		{LL{Lat: -33.8675, Lon: 151.207}, GEOREFHundredthMinute, "YDBM12420795", nil},
+		{LL{Lat: 0, Lon: 0}, GEOREFMinute, "NGAA0000", nil},
+		{LL{Lat: -90, Lon: -180}, GEOREFMinute, "AAAA0000", nil},
+		{LL{Lat: 90, Lon: 180}, GEOREFMinute, "AMAQ0059", nil}, // north pole and 180°E are encoded into the adjacent cell
+		// negative tests
+		{LL{Lat: 0, Lon: 0}, GEOREFPrecision(6), "", fmt.Errorf("invalid georef precision (2, 4, 8, 10 or 12 characters), precision = 6")},
+		{LL{Lat: 0, Lon: 190}, GEOREFMinute, "", fmt.Errorf("invalid longitude, lon = 190")},
+		{LL{Lat: 0, Lon: math.NaN()}, GEOREFMinute, "", fmt.Errorf("invalid longitude, lon = NaN")},
+		{LL{Lat: math.NaN(), Lon: 0}, GEOREFMinute, "", fmt.Errorf("invalid latitude, lat = NaN")},
+	}
+
+	for _, test := range tests {
+		georef, err := test.ll.ToGEOREF(test.precision)
+		function := fmt.Sprintf("%#v.ToGEOREF(%d)", test.ll, test.precision)
+		got := fmt.Sprintf("%s %v", georef, err)
+		want := fmt.Sprintf("%s %v", test.georef, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestGEOREF_Bounds(t *testing.T) {
+
+	var tests = []struct {
+		georef    GEOREF          // in
+		bbox      BoundingBox     // out
+		ll        LL              // out
+		precision GEOREFPrecision // out
+		err       error           // out
+	}{
+		// positive tests
+		{"GJ", BoundingBox{South: 30, West: -90, North: 45, East: -75}, LL{Lat: 37.5, Lon: -82.5}, GEOREFTile, nil},
+		{"GJPJ", BoundingBox{South: 38, West: -77, North: 39, East: -76}, LL{Lat: 38.5, Lon: -76.5}, GEOREFDegree, nil},
+		{"GJPJ3217", BoundingBox{South: 38.283333, West: -76.466667, North: 38.3, East: -76.45}, LL{Lat: 38.291667, Lon: -76.458333}, GEOREFMinute, nil},
+		{"gjpj325172", BoundingBox{South: 38.286667, West: -76.458333, North: 38.288333, East: -76.456667}, LL{Lat: 38.2875, Lon: -76.4575}, GEOREFTenthMinute, nil},
+		{"GJPJ32501720", BoundingBox{South: 38.286667, West: -76.458333, North: 38.286833, East: -76.458167}, LL{Lat: 38.28675, Lon: -76.45825}, GEOREFHundredthMinute, nil},
+		{"ZMQQ59995999", BoundingBox{South: 89.999833, West: 179.999833, North: 90, East: 180}, LL{Lat: 89.999917, Lon: 179.999917}, GEOREFHundredthMinute, nil},
+		// negative tests
+		{"GJP", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid georef length (2, 4, 8, 10 or 12 characters), georef = GJP")},
+		{"GJPJ321", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid georef length (2, 4, 8, 10 or 12 characters), georef = GJPJ321")},
+		{"IJPJ", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid longitude tile (A-Z without I and O), georef = IJPJ")},
+		{"GNPJ", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid latitude tile (A-M without I), georef = GNPJ")},
+		{"GJRJ", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid longitude degree (A-Q without I and O), georef = GJRJ")},
+		{"GJPO", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid latitude degree (A-Q without I and O), georef = GJPO")},
+		{"GJPJ6017", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid longitude minutes (00-59), georef = GJPJ6017")},
+		{"GJPJ3260", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid latitude minutes (00-59), georef = GJPJ3260")},
+		{"GJPJ32A7", BoundingBox{}, LL{}, 0, fmt.Errorf("invalid latitude minutes (00-59), georef = GJPJ32A7")},
+	}
+
+	for _, test := range tests {
+		bbox, err := test.georef.Bounds()
+		function := fmt.Sprintf("%s.Bounds()", test.georef)
+		got := fmt.Sprintf("%s %d %v", bbox, test.georef.Precision(), err)
+		want := fmt.Sprintf("%s %d %v", test.bbox, test.precision, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		ll, err := test.georef.ToLL()
+		function = fmt.Sprintf("%s.ToLL()", test.georef)
+		got = fmt.Sprintf("%s %v", ll, err)
+		want = fmt.Sprintf("%s %v", test.ll, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestParseGEOREF(t *testing.T) {
+
+	var tests = []struct {
+		s      string // in
+		georef GEOREF // out
+		format string // out
+		err    error  // out
+	}{
+		// positive tests
+		{"GJPJ3217", "GJPJ3217", "GJ PJ 32 17", nil},
+		{" gj pj 32 17 ", "GJPJ3217", "GJ PJ 32 17", nil},
+		{"GJPJ 325 172", "GJPJ325172", "GJ PJ 325 172", nil},
+		{"g j", "GJ", "GJ", nil},
+		{"GJ PJ", "GJPJ", "GJ PJ", nil},
+		// negative tests
+		{"GJ PJ 32 1", "", "", fmt.Errorf("invalid georef length (2, 4, 8, 10 or 12 characters), georef = GJPJ321")},
+		{"GJ-PJ", "", "", fmt.Errorf("invalid georef length (2, 4, 8, 10 or 12 characters), georef = GJ-PJ")},
+	}
+
+	for _, test := range tests {
+		georef, err := ParseGEOREF(test.s)
+		function := fmt.Sprintf("ParseGEOREF(%q)", test.s)
+		got := fmt.Sprintf("%s %v", georef, err)
+		want := fmt.Sprintf("%s %v", test.georef, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+		if err != nil {
+			continue
+		}
+
+		format, err := georef.Format()
+		function = fmt.Sprintf("%s.Format()", georef)
+		got = fmt.Sprintf("%s %v", format, err)
+		want = fmt.Sprintf("%s %v", test.format, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestGEOREF_RoundTrip(t *testing.T) {
+
+	// cell center back to the same cell
+	for _, georef := range []GEOREF{"GJ", "GJPJ", "GJPJ3217", "GJPJ325172", "GJPJ32501720", "AAAA0000", "ZMQQ59995999", "NGAA00000000"} {
+		ll, err := georef.ToLL()
+		if err != nil {
+			t.Errorf("\n%s.ToLL() -> %v\n", georef, err)
+			continue
+		}
+		got, err := ll.ToGEOREF(georef.Precision())
+		if got != georef || err != nil {
+			t.Errorf("\n%s -> %s -> %s %v\n", georef, ll, got, err)
+		}
+	}
+
+	// MGRS cell (south-west corner) back to the GEOREF cell
+	mgrs, err := GEOREF("GJPJ3217").ToMGRS(1)
+	if err != nil {
+		t.Errorf("\nGJPJ3217.ToMGRS(1) -> %v\n", err)
+	}
+	georef, err := mgrs.ToGEOREF(GEOREFMinute)
+	got := fmt.Sprintf("%s %s %v", mgrs, georef, err)
+	want := "18SUH7246739182 GJPJ3217 <nil>"
+	if got != want {
+		t.Errorf("\nGJPJ3217.ToMGRS(1).ToGEOREF(8) -> %s != %s\n", got, want)
+	}
+}
+
+func ExampleParseGEOREF() {
+
+	georef, err := ParseGEOREF("gj pj 32 17")
+	if err != nil {
+		log.Printf("error <%v> at ParseGEOREF()", err)
+		return
+	}
+	ll, err := georef.ToLL()
+	if err != nil {
+		log.Printf("error <%v> at georef.ToLL()", err)
+		return
+	}
+	fmt.Println(georef)
+	fmt.Println(ll)
+
+	// Output:
+	// GJPJ3217
+	// 38.291667 -76.458333
+}
@@ -0,0 +1,238 @@
+/*
+Purpose:
+- UTM hemisphere notation (32N/32S) <-> UTM latitude band notation (32U)
+
+Description:
+- Parsing and formatting of UTM coordinates in both conventions:
+  latitude band letter (MGRS style, e.g. "32U 399000 5757000") and
+  hemisphere letter (GIS/EPSG style, e.g. "32N 399000 5757000").
+
+Remarks:
+- The letter 'S' is ambiguous: latitude band S (32°N to 40°N) or southern hemisphere.
+  The letter 'N' is harmless: latitude band N (0°N to 8°N) is in the northern hemisphere.
+*/
+
+package coco
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UTMNotation defines how the letter after the UTM zone number is interpreted
+type UTMNotation int
+
+// UTM notations
+const (
+	NotationAuto       UTMNotation = iota // band or hemisphere, ambiguous input is rejected
+	NotationBand                          // latitude band letter (C-X)
+	NotationHemisphere                    // hemisphere letter (N or S)
+)
+
+// ErrAmbiguousZoneLetter is returned if a zone letter can be read as latitude band and as hemisphere.
+var ErrAmbiguousZoneLetter = errors.New("ambiguous zone letter (latitude band or hemisphere)")
+
+/*
+String returns the name of the UTM notation.
+*/
+func (notation UTMNotation) String() string {
+
+	switch notation {
+	case NotationAuto:
+		return "auto"
+	case NotationBand:
+		return "band"
+	case NotationHemisphere:
+		return "hemisphere"
+	}
+
+	return fmt.Sprintf("UTMNotation(%d)", int(notation))
+}
+
+/*
+NewUTMHemisphere creates UTM (with latitude band letter) from hemisphere notation (WGS84 ellipsoid).
+zone holds the zone number (1-60).
+hemisphere holds the hemisphere (NorthernHemisphere or SouthernHemisphere).
+easting, northing hold the UTM coordinate in meters.
+*/
+func NewUTMHemisphere(zone int, hemisphere Hemisphere, easting, northing float64) (UTM, error) {
+
+	if zone < 1 || zone > 60 {
+		return UTM{}, fmt.Errorf("invalid zone number, zone number = %v", zone)
+	}
+
+	// any band letter of the hemisphere is sufficient for the inverse projection
+	utm := UTM{ZoneNumber: zone, ZoneLetter: 'N', Easting: easting, Northing: northing}
+	switch hemisphere {
+	case NorthernHemisphere:
+	case SouthernHemisphere:
+		utm.ZoneLetter = 'M'
+	default:
+		return UTM{}, fmt.Errorf("invalid hemisphere, hemisphere = %c", hemisphere)
+	}
+
+	ll, err := utm.ToLL()
+	if err != nil {
+		return UTM{}, fmt.Errorf("error <%v> at utm.ToLL(), utm = %#v", err, utm)
+	}
+	if ll.Lat < -80 || ll.Lat > 84 {
+		return UTM{}, fmt.Errorf("latitude outside of utm range (80°S to 84°N), lat = %v", ll.Lat)
+	}
+
+	switch {
+	case hemisphere == SouthernHemisphere && ll.Lat > 0:
+		return UTM{}, fmt.Errorf("northing not in southern hemisphere, northing = %v", northing)
+	case hemisphere == SouthernHemisphere && ll.Lat == 0:
+		// the equator in southern hemisphere notation is the upper border of band M
+	case hemisphere == NorthernHemisphere && ll.Lat < 0:
+		return UTM{}, fmt.Errorf("northing not in northern hemisphere, northing = %v", northing)
+	default:
+		utm.ZoneLetter = getLetterDesignator(ll.Lat)
+	}
+
+	return utm, nil
+}
+
+/*
+HemisphereString returns stringified UTM object in hemisphere notation (e.g. "32N 399000 5757000").
+*/
+func (utm UTM) HemisphereString() string {
+
+	return utm.FormatHemisphere(0, RoundHalfUp)
+}
+
+/*
+FormatHemisphere returns stringified UTM object in hemisphere notation with the given number of decimals.
+decimals holds the number of decimals for easting and northing (0 = meters, 2 = centimeters, ...).
+rounding holds the rounding mode (RoundHalfUp or Truncate).
+*/
+func (utm UTM) FormatHemisphere(decimals int, rounding Rounding) string {
+
+	utmHemisphere := utm
+	utmHemisphere.ZoneLetter = byte(utm.Hemisphere())
+
+	return utmHemisphere.Format(decimals, rounding)
+}
+
+/*
+ParseUTM parses a UTM string in band or hemisphere notation (e.g. "32U 399000 5757000" or "32N 399000 5757000").
+Zone number and letter may be separated by whitespace, letters are case insensitive.
+The returned UTM object always carries the latitude band letter.
+s holds the UTM string.
+notation holds the interpretation of the zone letter (NotationAuto, NotationBand or NotationHemisphere).
+*/
+func ParseUTM(s string, notation UTMNotation) (UTM, error) {
+
+	fields := strings.Fields(strings.ToUpper(s))
+
+	// split "32U" into "32" and "U"
+	if len(fields) == 3 && len(fields[0]) > 1 {
+		last := len(fields[0]) - 1
+		fields = append([]string{fields[0][:last], fields[0][last:]}, fields[1:]...)
+	}
+	if len(fields) != 4 || len(fields[1]) != 1 {
+		return UTM{}, fmt.Errorf("invalid utm format, utm = %s", s)
+	}
+
+	zone, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return UTM{}, fmt.Errorf("error <%v> at strconv.Atoi(), zone string = %v", err, fields[0])
+	}
+	if zone < 1 || zone > 60 {
+		return UTM{}, fmt.Errorf("invalid zone number, zone number = %v", zone)
+	}
+
+	letter := fields[1][0]
+
+	easting, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return UTM{}, fmt.Errorf("error <%v> at strconv.ParseFloat(), easting string = %v", err, fields[2])
+	}
+	northing, err := strconv.ParseFloat(fields[3], 64)
+	if err != nil {
+		return UTM{}, fmt.Errorf("error <%v> at strconv.ParseFloat(), northing string = %v", err, fields[3])
+	}
+
+	switch notation {
+	case NotationBand:
+		return newUTMBand(zone, letter, easting, northing)
+	case NotationHemisphere:
+		return NewUTMHemisphere(zone, Hemisphere(letter), easting, northing)
+	case NotationAuto:
+		return newUTMAuto(zone, letter, easting, northing)
+	}
+
+	return UTM{}, fmt.Errorf("invalid utm notation, notation = %v", notation)
+}
+
+/*
+newUTMBand creates UTM from latitude band notation.
+*/
+func newUTMBand(zone int, letter byte, easting, northing float64) (UTM, error) {
+
+	if letter < 'C' || letter > 'X' || letter == 'I' || letter == 'O' {
+		return UTM{}, fmt.Errorf("invalid latitude band letter, letter = %c", letter)
+	}
+
+	return UTM{ZoneNumber: zone, ZoneLetter: letter, Easting: easting, Northing: northing}, nil
+}
+
+/*
+newUTMAuto creates UTM from band or hemisphere notation. The letter 'S' is resolved by the northing
+if possible (a position in band S lies between 32°N and 40°N), otherwise ErrAmbiguousZoneLetter is returned.
+*/
+func newUTMAuto(zone int, letter byte, easting, northing float64) (UTM, error) {
+
+	switch letter {
+	case 'N':
+		// band N and northern hemisphere denote the same position
+		return NewUTMHemisphere(zone, NorthernHemisphere, easting, northing)
+	case 'S':
+		band, errBand := newUTMBand(zone, letter, easting, northing)
+		if errBand == nil && !band.inBand() {
+			errBand = fmt.Errorf("northing outside of latitude band S")
+		}
+		hemisphere, errHemisphere := NewUTMHemisphere(zone, SouthernHemisphere, easting, northing)
+
+		switch {
+		case errBand == nil && errHemisphere == nil:
+			return UTM{}, fmt.Errorf("%w, zone letter = %c, northing = %v", ErrAmbiguousZoneLetter, letter, northing)
+		case errBand == nil:
+			return band, nil
+		case errHemisphere == nil:
+			return hemisphere, nil
+		}
+		return UTM{}, fmt.Errorf("error <%v> at NewUTMHemisphere()", errHemisphere)
+	}
+
+	return newUTMBand(zone, letter, easting, northing)
+}
+
+/*
+inBand reports whether the UTM position lies in (or within 0.5 degree of) its latitude band.
+*/
+func (utm UTM) inBand() bool {
+
+	ll, err := utm.ToLL()
+	if err != nil {
+		return false
+	}
+
+	index := strings.IndexByte(bandLetters, utm.ZoneLetter)
+	if index < 0 {
+		return false
+	}
+
+	south := float64(index)*8 - 80
+	north := south + 8
+	if utm.ZoneLetter == 'X' {
+		north = 84
+	}
+
+	return ll.Lat >= south-0.5 && ll.Lat <= north+0.5
+}
+
+// bandLetters defines the UTM latitude band letters from 80°S to 84°N
+const bandLetters = "CDEFGHJKLMNPQRSTUVWX"
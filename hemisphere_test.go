@@ -0,0 +1,94 @@
+/*
+Purpose:
+- UTM hemisphere notation (32N/32S) <-> UTM latitude band notation (32U)
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"testing"
+)
+
+func TestParseUTM(t *testing.T) {
+
+	var tests = []struct {
+		s        string      // in
+		notation UTMNotation // in
+		utm      UTM         // out
+		err      error       // out
+	}{
+		// positive tests
+		{"32U 399000 5757000", NotationBand, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 399000, Northing: 5757000}, nil},
+		{"32u 399000.5 5757000.25", NotationAuto, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 399000.5, Northing: 5757000.25}, nil},
+		{"32 N 399000 5757000", NotationHemisphere, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 399000, Northing: 5757000}, nil},
+		{"32N 399000 5757000", NotationAuto, UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 399000, Northing: 5757000}, nil},
+		{"56S 334873 6252266", NotationHemisphere, UTM{ZoneNumber: 56, ZoneLetter: 'H', Easting: 334873, Northing: 6252266}, nil},
+		{"56S 334873 6252266", NotationAuto, UTM{ZoneNumber: 56, ZoneLetter: 'H', Easting: 334873, Northing: 6252266}, nil}, // northing outside of band S
+		{"11S 672349 4011844", NotationBand, UTM{ZoneNumber: 11, ZoneLetter: 'S', Easting: 672349, Northing: 4011844}, nil},
+		{"11S 672349 4011844", NotationHemisphere, UTM{ZoneNumber: 11, ZoneLetter: 'F', Easting: 672349, Northing: 4011844}, nil},
+		{"31N 166021 0", NotationHemisphere, UTM{ZoneNumber: 31, ZoneLetter: 'N', Easting: 166021, Northing: 0}, nil},
+		{"31S 166021 10000000", NotationHemisphere, UTM{ZoneNumber: 31, ZoneLetter: 'M', Easting: 166021, Northing: 10000000}, nil},
+		// negative tests
+		{"11S 672349 4011844", NotationAuto, UTM{}, fmt.Errorf("ambiguous zone letter (latitude band or hemisphere), zone letter = S, northing = 4.011844e+06")},
+		{"32U 399000 5757000", NotationHemisphere, UTM{}, fmt.Errorf("invalid hemisphere, hemisphere = U")},
+		{"32I 399000 5757000", NotationBand, UTM{}, fmt.Errorf("invalid latitude band letter, letter = I")},
+		{"61U 399000 5757000", NotationBand, UTM{}, fmt.Errorf("invalid zone number, zone number = 61")},
+		{"32U 399000", NotationBand, UTM{}, fmt.Errorf("invalid utm format, utm = 32U 399000")},
+		{"32U 399000 57570O0", NotationBand, UTM{}, fmt.Errorf("error <strconv.ParseFloat: parsing \"57570O0\": invalid syntax> at strconv.ParseFloat(), northing string = 57570O0")},
+	}
+
+	for _, test := range tests {
+		utm, err := ParseUTM(test.s, test.notation)
+		function := fmt.Sprintf("ParseUTM(%s, %s)", test.s, test.notation)
+		got := fmt.Sprintf("%#v %v", utm, err)
+		want := fmt.Sprintf("%#v %v", test.utm, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+
+	_, err := ParseUTM("11S 672349 4011844", NotationAuto)
+	if !errors.Is(err, ErrAmbiguousZoneLetter) {
+		t.Errorf("\nParseUTM(11S 672349 4011844, auto) -> %v is not ErrAmbiguousZoneLetter\n", err)
+	}
+}
+
+func TestUTM_HemisphereString(t *testing.T) {
+
+	var tests = []struct {
+		utm UTM    // in
+		s   string // out
+	}{
+		// positive tests
+		{UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 399000, Northing: 5757000}, "32N 399000 5757000"},
+		{UTM{ZoneNumber: 11, ZoneLetter: 'S', Easting: 672349, Northing: 4011844}, "11N 672349 4011844"},
+		{UTM{ZoneNumber: 56, ZoneLetter: 'H', Easting: 334873, Northing: 6252266}, "56S 334873 6252266"},
+		{UTM{ZoneNumber: 30, ZoneLetter: 'M', Easting: 722561.74, Northing: 9889402.03}, "30S 722562 9889402"},
+	}
+
+	for _, test := range tests {
+		function := fmt.Sprintf("utm = %s, HemisphereString()", test.utm)
+		got := test.utm.HemisphereString()
+		want := test.s
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleParseUTM() {
+
+	utm, err := ParseUTM("56S 334873 6252266", NotationHemisphere)
+	if err != nil {
+		log.Fatalf("error <%v> at ParseUTM()", err)
+	}
+	fmt.Printf("%s -> %s\n", utm.HemisphereString(), utm)
+	// Output:
+	// 56S 334873 6252266 -> 56H 334873 6252266
+}
@@ -0,0 +1,244 @@
+/*
+Purpose:
+- Irish Grid (TM65) and Irish Transverse Mercator (ITM, IRENET95) <-> Lon Lat
+
+Description:
+- Irish Grid: transverse Mercator on the Airy Modified ellipsoid (TM65 datum) with single letter
+  100 km squares (e.g. "O 15904 34671") for Ireland and Northern Ireland.
+- ITM: transverse Mercator on the GRS80 ellipsoid (IRENET95, ETRS89 compatible), full easting/northing.
+
+Remarks:
+- Irish Grid parameters: latitude of origin 53.5°N, central meridian 8°W, scale 1.000035,
+  false easting 200000 m, false northing 250000 m.
+- ITM parameters: latitude of origin 53.5°N, central meridian 8°W, scale 0.99982,
+  false easting 600000 m, false northing 750000 m.
+- Conversion between TM65 and WGS84 uses the Helmert transformation of the TM65 datum (accuracy about 1 m).
+
+Links:
+- https://www.osi.ie/wp-content/uploads/2015/05/transformations_booklet.pdf
+- https://epsg.io/29902
+- https://epsg.io/2157
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+)
+
+// IrishGrid defines coordinate in Irish Grid (TM65)
+type IrishGrid struct {
+	Easting  float64
+	Northing float64
+}
+
+// ITM defines coordinate in Irish Transverse Mercator (IRENET95)
+type ITM struct {
+	Easting  float64
+	Northing float64
+}
+
+// Irish Grid extent in meters (5 x 5 squares of 100 km)
+const irishGridMax = 500000.0
+
+/*
+irishGridProjection returns the transverse Mercator parameters of the Irish Grid.
+*/
+func irishGridProjection() transverseMercator {
+
+	return transverseMercator{
+		ellipsoid:     EllipsoidAiryModified,
+		engine:        Krueger,
+		lat0:          53.5,
+		lon0:          -8,
+		k0:            1.000035,
+		falseEasting:  200000,
+		falseNorthing: 250000,
+	}
+}
+
+/*
+itmProjection returns the transverse Mercator parameters of the Irish Transverse Mercator.
+*/
+func itmProjection() transverseMercator {
+
+	return transverseMercator{
+		ellipsoid:     EllipsoidGRS80,
+		engine:        Krueger,
+		lat0:          53.5,
+		lon0:          -8,
+		k0:            0.99982,
+		falseEasting:  600000,
+		falseNorthing: 750000,
+	}
+}
+
+/*
+String returns stringified IrishGrid object (full easting and northing, rounded to meters).
+*/
+func (ig IrishGrid) String() string {
+
+	return fmt.Sprintf("%.0f %.0f", roundValue(ig.Easting, 1, RoundHalfUp), roundValue(ig.Northing, 1, RoundHalfUp))
+}
+
+/*
+String returns stringified ITM object (full easting and northing, rounded to meters).
+*/
+func (itm ITM) String() string {
+
+	return fmt.Sprintf("%.0f %.0f", roundValue(itm.Easting, 1, RoundHalfUp), roundValue(itm.Northing, 1, RoundHalfUp))
+}
+
+/*
+inGrid reports whether the coordinate lies inside the Irish Grid (0-500 km east and north).
+*/
+func (ig IrishGrid) inGrid() bool {
+
+	return ig.Easting >= 0 && ig.Easting < irishGridMax && ig.Northing >= 0 && ig.Northing < irishGridMax
+}
+
+/*
+ToIrishGrid converts Lon Lat (WGS84 datum) to Irish Grid.
+*/
+func (ll LL) ToIrishGrid() (IrishGrid, error) {
+
+	return ll.ToIrishGridDatum(WGS84)
+}
+
+/*
+ToIrishGridDatum converts Lon Lat to Irish Grid.
+datum holds the datum of the Lon Lat coordinate (e.g. WGS84 or TM65).
+*/
+func (ll LL) ToIrishGridDatum(datum Datum) (IrishGrid, error) {
+
+	if ll.Lon < -180 || ll.Lon > 180 {
+		return IrishGrid{}, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if ll.Lat < -90 || ll.Lat > 90 {
+		return IrishGrid{}, fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	llTM65 := ll.Transform(datum, TM65)
+
+	ig := IrishGrid{}
+	ig.Easting, ig.Northing = irishGridProjection().forward(llTM65.Lat, llTM65.Lon)
+	if !ig.inGrid() {
+		return IrishGrid{}, fmt.Errorf("position outside of irish grid, ll = %s", ll)
+	}
+
+	return ig, nil
+}
+
+/*
+ToLL converts Irish Grid to Lon Lat (WGS84 datum).
+*/
+func (ig IrishGrid) ToLL() (LL, error) {
+
+	return ig.ToLLDatum(WGS84)
+}
+
+/*
+ToLLDatum converts Irish Grid to Lon Lat.
+datum holds the wanted datum of the Lon Lat coordinate (e.g. WGS84 or TM65).
+*/
+func (ig IrishGrid) ToLLDatum(datum Datum) (LL, error) {
+
+	if !ig.inGrid() {
+		return LL{}, fmt.Errorf("position outside of irish grid, irishgrid = %s", ig)
+	}
+
+	ll := LL{}
+	ll.Lat, ll.Lon = irishGridProjection().inverse(ig.Easting, ig.Northing)
+
+	return ll.Transform(TM65, datum), nil
+}
+
+/*
+GridRef returns the letter-prefixed grid reference (e.g. "O 159 346" for 6 digits).
+Easting and northing are truncated to the accuracy (grid reference convention).
+digits holds the total number of digits (2, 4, 6, 8 or 10).
+*/
+func (ig IrishGrid) GridRef(digits int) (string, error) {
+
+	if digits < 2 || digits > 10 || digits%2 != 0 {
+		return "", fmt.Errorf("invalid number of digits (2, 4, 6, 8 or 10), digits = %d", digits)
+	}
+	if !ig.inGrid() {
+		return "", fmt.Errorf("position outside of irish grid, irishgrid = %s", ig)
+	}
+
+	easting := roundValue(ig.Easting, 1, Truncate)
+	northing := roundValue(ig.Northing, 1, Truncate)
+	e100k := int(math.Floor(easting / 100000))
+	n100k := int(math.Floor(northing / 100000))
+
+	// 5x5 letter grid, 'A' in the north-west, 'V' in the south-west
+	letter := gridLetter((4-n100k)*5 + e100k)
+
+	half := digits / 2
+	return fmt.Sprintf("%c %s %s", letter,
+		mgrsDigits(math.Mod(easting, 100000), half), mgrsDigits(math.Mod(northing, 100000), half)), nil
+}
+
+/*
+ParseIrishGridRef parses a letter-prefixed Irish Grid reference (e.g. "O 15904 34671", "o1590434671", "O 1 3").
+The returned coordinate is the south-west corner of the referenced square, the accuracy is returned in meters.
+s holds the grid reference.
+*/
+func ParseIrishGridRef(s string) (IrishGrid, int, error) {
+
+	letters, fields, err := splitGridRef(s, 1)
+	if err != nil {
+		return IrishGrid{}, 0, err
+	}
+
+	index := gridIndex(letters[0])
+	e100k := index % 5
+	n100k := 4 - index/5
+
+	easting, northing, accuracy, err := gridRefDigits(s, fields)
+	if err != nil {
+		return IrishGrid{}, 0, err
+	}
+
+	ig := IrishGrid{}
+	ig.Easting = float64(e100k*100000) + easting
+	ig.Northing = float64(n100k*100000) + northing
+
+	return ig, accuracy, nil
+}
+
+/*
+ToITM converts Lon Lat (WGS84 datum) to Irish Transverse Mercator.
+*/
+func (ll LL) ToITM() (ITM, error) {
+
+	if ll.Lon < -180 || ll.Lon > 180 {
+		return ITM{}, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if ll.Lat < -90 || ll.Lat > 90 {
+		return ITM{}, fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	llETRS89 := ll.Transform(WGS84, ETRS89)
+
+	itm := ITM{}
+	itm.Easting, itm.Northing = itmProjection().forward(llETRS89.Lat, llETRS89.Lon)
+
+	return itm, nil
+}
+
+/*
+ToLL converts Irish Transverse Mercator to Lon Lat (WGS84 datum).
+*/
+func (itm ITM) ToLL() (LL, error) {
+
+	ll := LL{}
+	ll.Lat, ll.Lon = itmProjection().inverse(itm.Easting, itm.Northing)
+	if math.IsNaN(ll.Lat) || math.IsNaN(ll.Lon) {
+		return LL{}, fmt.Errorf("invalid itm coordinate, itm = %s", itm)
+	}
+
+	return ll.Transform(ETRS89, WGS84), nil
+}
@@ -0,0 +1,178 @@
+/*
+Purpose:
+- Irish Grid (TM65) and Irish Transverse Mercator (ITM, IRENET95) <-> Lon Lat
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"testing"
+)
+
+func TestLL_ToIrishGrid(t *testing.T) {
+
+	var tests = []struct {
+		ll        LL        // in
+		irishGrid IrishGrid // out
+		gridRef   string    // out
+		err       error     // out
+	}{
+		// positive tests
+		{LL{Lat: 53.349804, Lon: -6.260310}, IrishGrid{Easting: 315899.885, Northing: 234671.788}, "O 15899 34671", nil}, // Dublin
+		{LL{Lat: 54.597285, Lon: -5.930120}, IrishGrid{Easting: 333827.092, Northing: 374085.799}, "J 33827 74085", nil}, // Belfast
+		{LL{Lat: 51.898500, Lon: -8.475600}, IrishGrid{Easting: 167311.821, Northing: 71861.032}, "W 67311 71861", nil},  // Cork
+		// negative tests
+		{LL{Lat: 50.0, Lon: -20.0}, IrishGrid{}, "", fmt.Errorf("position outside of irish grid, ll = 50.000000 -20.000000")},
+	}
+
+	for _, test := range tests {
+		irishGrid, err := test.ll.ToIrishGrid()
+		gridRef := ""
+		if err == nil {
+			gridRef, _ = irishGrid.GridRef(10)
+		}
+		function := fmt.Sprintf("%#v.ToIrishGrid()", test.ll)
+		got := fmt.Sprintf("%.3f %.3f %s %v", irishGrid.Easting, irishGrid.Northing, gridRef, err)
+		want := fmt.Sprintf("%.3f %.3f %s %v", test.irishGrid.Easting, test.irishGrid.Northing, test.gridRef, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		// round trip
+		if err == nil {
+			ll, err := irishGrid.ToLL()
+			got = fmt.Sprintf("%s %v", ll, err)
+			want = fmt.Sprintf("%s %v", test.ll, nil)
+			if got != want {
+				t.Errorf("\n%#v.ToLL() -> %s != %s\n", irishGrid, got, want)
+			}
+		}
+	}
+}
+
+func TestIrishGrid_GridRef(t *testing.T) {
+
+	var tests = []struct {
+		irishGrid IrishGrid // in
+		digits    int       // in
+		gridRef   string    // out
+		err       error     // out
+	}{
+		// positive tests
+		{IrishGrid{Easting: 315899.885, Northing: 234671.788}, 6, "O 158 346", nil},
+		{IrishGrid{Easting: 315899.885, Northing: 234671.788}, 2, "O 1 3", nil},
+		{IrishGrid{Easting: 0, Northing: 0}, 4, "V 00 00", nil},
+		{IrishGrid{Easting: 499999, Northing: 499999}, 4, "E 99 99", nil},
+		// negative tests
+		{IrishGrid{Easting: 315899, Northing: 234671}, 12, "", fmt.Errorf("invalid number of digits (2, 4, 6, 8 or 10), digits = 12")},
+		{IrishGrid{Easting: 500000, Northing: 234671}, 10, "", fmt.Errorf("position outside of irish grid, irishgrid = 500000 234671")},
+	}
+
+	for _, test := range tests {
+		gridRef, err := test.irishGrid.GridRef(test.digits)
+		function := fmt.Sprintf("%#v.GridRef(%d)", test.irishGrid, test.digits)
+		got := fmt.Sprintf("%s %v", gridRef, err)
+		want := fmt.Sprintf("%s %v", test.gridRef, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestParseIrishGridRef(t *testing.T) {
+
+	var tests = []struct {
+		s         string    // in
+		irishGrid IrishGrid // out
+		accuracy  int       // out
+		err       error     // out
+	}{
+		// positive tests
+		{"O 15899 34671", IrishGrid{Easting: 315899, Northing: 234671}, 1, nil},
+		{"o1589934671", IrishGrid{Easting: 315899, Northing: 234671}, 1, nil},
+		{"O 1 3", IrishGrid{Easting: 310000, Northing: 230000}, 10000, nil},
+		{"V", IrishGrid{Easting: 0, Northing: 0}, 100000, nil},
+		{"Z 9 9", IrishGrid{Easting: 490000, Northing: 90000}, 10000, nil},
+		// negative tests
+		{"I 1 1", IrishGrid{}, 0, fmt.Errorf("invalid grid letter 'I', gridref = I 1 1")},
+		{"O 123", IrishGrid{}, 0, fmt.Errorf("invalid number of digits (0-10, even), gridref = O 123")},
+		{"", IrishGrid{}, 0, fmt.Errorf("missing grid letter, gridref = ")},
+	}
+
+	for _, test := range tests {
+		irishGrid, accuracy, err := ParseIrishGridRef(test.s)
+		function := fmt.Sprintf("ParseIrishGridRef(%s)", test.s)
+		got := fmt.Sprintf("%s %d %v", irishGrid, accuracy, err)
+		want := fmt.Sprintf("%s %d %v", test.irishGrid, test.accuracy, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_ToITM(t *testing.T) {
+
+	var tests = []struct {
+		ll  LL    // in
+		itm ITM   // out
+		err error // out
+	}{
+		// positive tests
+		{LL{Lat: 53.349804, Lon: -6.260310}, ITM{Easting: 715825.830, Northing: 734698.022}, nil},
+		{LL{Lat: 54.597285, Lon: -5.930120}, ITM{Easting: 733749.915, Northing: 874081.901}, nil},
+		{LL{Lat: 51.898500, Lon: -8.475600}, ITM{Easting: 567268.896, Northing: 571923.132}, nil},
+		// negative tests
+		{LL{Lat: 53.0, Lon: -181.0}, ITM{}, fmt.Errorf("invalid longitude, lon = -181")},
+	}
+
+	for _, test := range tests {
+		itm, err := test.ll.ToITM()
+		function := fmt.Sprintf("%#v.ToITM()", test.ll)
+		got := fmt.Sprintf("%.3f %.3f %v", itm.Easting, itm.Northing, err)
+		want := fmt.Sprintf("%.3f %.3f %v", test.itm.Easting, test.itm.Northing, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		// round trip
+		if err == nil {
+			ll, err := itm.ToLL()
+			got = fmt.Sprintf("%s %v", ll, err)
+			want = fmt.Sprintf("%s %v", test.ll, nil)
+			if got != want {
+				t.Errorf("\n%#v.ToLL() -> %s != %s\n", itm, got, want)
+			}
+		}
+	}
+}
+
+func ExampleLL_ToIrishGrid() {
+
+	ll := LL{Lat: 53.349804, Lon: -6.260310}
+	irishGrid, err := ll.ToIrishGrid()
+	if err != nil {
+		log.Printf("error <%v> at ll.ToIrishGrid()", err)
+		return
+	}
+	gridRef, err := irishGrid.GridRef(10)
+	if err != nil {
+		log.Printf("error <%v> at irishGrid.GridRef()", err)
+		return
+	}
+	itm, err := ll.ToITM()
+	if err != nil {
+		log.Printf("error <%v> at ll.ToITM()", err)
+		return
+	}
+	fmt.Println(gridRef)
+	fmt.Println(itm)
+
+	// Output:
+	// O 15899 34671
+	// 715826 734698
+}
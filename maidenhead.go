@@ -0,0 +1,271 @@
+/*
+Purpose:
+- Maidenhead locator (QTH locator) <-> Lon Lat, MGRS
+
+Description:
+- Maidenhead locators (e.g. "JO31le") encode a cell with pairs of longitude/latitude characters:
+  field (A-R, 20° x 10°), square (0-9, 2° x 1°), subsquare (a-x, 5' x 2.5'),
+  extended square (0-9, 30" x 15") and extended subsquare (a-x, 1.25" x 0.625").
+- Distance and bearing between two locators refer to the cell centers (great circle on a sphere,
+  as common in amateur radio).
+
+Remarks:
+- Locator length 2, 4, 6, 8 or 10 characters, case insensitive. Canonical form: field upper case,
+  subsquare lower case (e.g. "JO31le").
+
+Links:
+- https://en.wikipedia.org/wiki/Maidenhead_Locator_System
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Maidenhead defines a cell in Maidenhead locator notation (QTH locator)
+type Maidenhead string
+
+// maidenheadPair defines the character set and cell size (in units) of a locator pair
+type maidenheadPair struct {
+	base     byte // first character ('A', '0' or 'a')
+	count    int  // number of characters
+	lonUnits int  // cell width in units of 1/2880°
+	latUnits int  // cell height in units of 1/5760°
+}
+
+// maidenheadPairs holds the locator pairs (field, square, subsquare, extended square, extended subsquare)
+var maidenheadPairs = []maidenheadPair{
+	{'A', 18, 57600, 57600}, // field 20° x 10°
+	{'0', 10, 5760, 5760},   // square 2° x 1°
+	{'a', 24, 240, 240},     // subsquare 5' x 2.5'
+	{'0', 10, 24, 24},       // extended square 30" x 15"
+	{'a', 24, 1, 1},         // extended subsquare 1.25" x 0.625"
+}
+
+// Maidenhead unit resolution (units per degree)
+const (
+	maidenheadLonUnits = 2880.0
+	maidenheadLatUnits = 5760.0
+)
+
+// meanEarthRadius holds the mean earth radius in meters (IUGG, R1)
+const meanEarthRadius = 6371008.8
+
+/*
+ToMaidenhead converts Lon Lat to Maidenhead locator.
+length holds the wanted locator length (2, 4, 6, 8 or 10 characters).
+*/
+func (ll LL) ToMaidenhead(length int) (Maidenhead, error) {
+
+	if length < 2 || length > 2*len(maidenheadPairs) || length%2 != 0 {
+		return "", fmt.Errorf("invalid locator length (2, 4, 6, 8 or 10), length = %d", length)
+	}
+	if !(ll.Lon >= -180 && ll.Lon <= 180) {
+		return "", fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if !(ll.Lat >= -90 && ll.Lat <= 90) {
+		return "", fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	lonVal := cellIndex(normalizeLon(ll.Lon)+180, maidenheadLonUnits)
+	latVal := cellIndex(ll.Lat+90, maidenheadLatUnits)
+
+	// the north pole is encoded into the cell below
+	if latVal >= 180*maidenheadLatUnits {
+		latVal = 180*maidenheadLatUnits - 1
+	}
+
+	var sb strings.Builder
+	for _, pair := range maidenheadPairs[:length/2] {
+		sb.WriteByte(pair.base + byte(lonVal/pair.lonUnits))
+		sb.WriteByte(pair.base + byte(latVal/pair.latUnits))
+		lonVal %= pair.lonUnits
+		latVal %= pair.latUnits
+	}
+
+	return Maidenhead(sb.String()), nil
+}
+
+/*
+Validate checks the Maidenhead locator (length 2, 4, 6, 8 or 10, valid characters, case insensitive).
+*/
+func (mh Maidenhead) Validate() error {
+
+	if len(mh) < 2 || len(mh) > 2*len(maidenheadPairs) || len(mh)%2 != 0 {
+		return fmt.Errorf("invalid locator length (2, 4, 6, 8 or 10), locator = %s", mh)
+	}
+
+	for i := 0; i < len(mh); i++ {
+		pair := maidenheadPairs[i/2]
+		char := mh[i]
+		if pair.base != '0' {
+			char = strings.ToLower(string(char))[0] - 'a' + pair.base
+		}
+		if char < pair.base || int(char-pair.base) >= pair.count {
+			return fmt.Errorf("invalid locator character %q at position %d, locator = %s", mh[i], i+1, mh)
+		}
+	}
+
+	return nil
+}
+
+/*
+Normalize returns the canonical form of the locator (field upper case, subsquares lower case).
+*/
+func (mh Maidenhead) Normalize() (Maidenhead, error) {
+
+	if err := mh.Validate(); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(mh); i++ {
+		if maidenheadPairs[i/2].base == 'A' {
+			sb.WriteString(strings.ToUpper(string(mh[i])))
+		} else {
+			sb.WriteString(strings.ToLower(string(mh[i])))
+		}
+	}
+
+	return Maidenhead(sb.String()), nil
+}
+
+/*
+Bounds returns the bounding box of the locator cell.
+*/
+func (mh Maidenhead) Bounds() (BoundingBox, error) {
+
+	normalized, err := mh.Normalize()
+	if err != nil {
+		return BoundingBox{}, err
+	}
+
+	lonVal, latVal := 0, 0
+	pair := maidenheadPairs[0]
+	for i := 0; i < len(normalized); i += 2 {
+		pair = maidenheadPairs[i/2]
+		lonVal += int(normalized[i]-pair.base) * pair.lonUnits
+		latVal += int(normalized[i+1]-pair.base) * pair.latUnits
+	}
+
+	bbox := BoundingBox{}
+	bbox.West = float64(lonVal)/maidenheadLonUnits - 180
+	bbox.South = float64(latVal)/maidenheadLatUnits - 90
+	bbox.East = float64(lonVal+pair.lonUnits)/maidenheadLonUnits - 180
+	bbox.North = float64(latVal+pair.latUnits)/maidenheadLatUnits - 90
+
+	return bbox, nil
+}
+
+/*
+ToLL converts Maidenhead locator to Lon Lat (center of the cell).
+*/
+func (mh Maidenhead) ToLL() (LL, error) {
+
+	bbox, err := mh.Bounds()
+	if err != nil {
+		return LL{}, err
+	}
+
+	return bbox.Center(), nil
+}
+
+/*
+ToMGRS converts Maidenhead locator (center of the cell) to MGRS/UTMREF.
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+*/
+func (mh Maidenhead) ToMGRS(accuracy int) (MGRS, error) {
+
+	ll, err := mh.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at mh.ToLL(), locator = %s", err, mh)
+	}
+
+	return ll.ToMGRS(accuracy)
+}
+
+/*
+ToMaidenhead converts MGRS/UTMREF (south-west corner of the MGRS cell) to Maidenhead locator.
+length holds the wanted locator length (2, 4, 6, 8 or 10 characters).
+*/
+func (mgrs MGRS) ToMaidenhead(length int) (Maidenhead, error) {
+
+	ll, _, err := mgrs.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at mgrs.ToLL(), mgrs = %s", err, mgrs)
+	}
+
+	return ll.ToMaidenhead(length)
+}
+
+/*
+DistanceTo returns the great circle distance in meters between the centers of two locator cells.
+other holds the locator of the remote station.
+*/
+func (mh Maidenhead) DistanceTo(other Maidenhead) (float64, error) {
+
+	from, to, err := maidenheadCenters(mh, other)
+	if err != nil {
+		return 0, err
+	}
+	distance, _ := greatCircle(from, to)
+
+	return distance, nil
+}
+
+/*
+BearingTo returns the initial great circle bearing in degrees (0-360, clockwise from north)
+from the center of this locator cell to the center of the other locator cell.
+other holds the locator of the remote station.
+*/
+func (mh Maidenhead) BearingTo(other Maidenhead) (float64, error) {
+
+	from, to, err := maidenheadCenters(mh, other)
+	if err != nil {
+		return 0, err
+	}
+	_, bearing := greatCircle(from, to)
+
+	return bearing, nil
+}
+
+/*
+maidenheadCenters returns the cell centers of two locators.
+*/
+func maidenheadCenters(from, to Maidenhead) (LL, LL, error) {
+
+	fromLL, err := from.ToLL()
+	if err != nil {
+		return LL{}, LL{}, fmt.Errorf("error <%w> at from.ToLL(), locator = %s", err, from)
+	}
+	toLL, err := to.ToLL()
+	if err != nil {
+		return LL{}, LL{}, fmt.Errorf("error <%w> at to.ToLL(), locator = %s", err, to)
+	}
+
+	return fromLL, toLL, nil
+}
+
+/*
+greatCircle returns the distance in meters (haversine formula) and the initial bearing in degrees
+between two positions on a sphere with mean earth radius.
+*/
+func greatCircle(from, to LL) (float64, float64) {
+
+	lat1 := degToRad(from.Lat)
+	lat2 := degToRad(to.Lat)
+	dLat := lat2 - lat1
+	dLon := degToRad(to.Lon - from.Lon)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	distance := 2 * meanEarthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	bearing := math.Mod(radToDeg(math.Atan2(y, x))+360, 360)
+
+	return distance, bearing
+}
@@ -0,0 +1,188 @@
+/*
+Purpose:
+- Maidenhead locator (QTH locator) <-> Lon Lat, MGRS
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"testing"
+)
+
+func TestLL_ToMaidenhead(t *testing.T) {
+
+	var tests = []struct {
+		ll         LL         // in
+		length     int        // in
+		maidenhead Maidenhead // out
+		err        error      // out
+	}{
+		// positive tests (Wikipedia examples)
+		{LL{Lat: 48.14666, Lon: 11.60833}, 8, "JN58td25", nil}, // Munich
+		{LL{Lat: -34.91, Lon: -56.21166}, 8, "GF15vc41", nil},  // Montevideo
+		{LL{Lat: 38.92, Lon: -77.065}, 8, "FM18lw20", nil},     // Washington, DC
+		{LL{Lat: 41.714775, Lon: -72.72726}, 6, "FN31pr", nil}, // Newington, CT
+		{LL{Lat: 51.95, Lon: 7.53}, 10, "JO31sw38oa", nil},     // Münster
+		{LL{Lat: 51.95, Lon: 7.53}, 2, "JO", nil},
+		{LL{Lat: 90, Lon: 0}, 4, "JR09", nil}, // north pole is encoded into the cell below
+		{LL{Lat: -90, Lon: -180}, 6, "AA00aa", nil},
+		// negative tests
+		{LL{Lat: 51.95, Lon: 7.53}, 5, "", fmt.Errorf("invalid locator length (2, 4, 6, 8 or 10), length = 5")},
+		{LL{Lat: 51.95, Lon: 7.53}, 12, "", fmt.Errorf("invalid locator length (2, 4, 6, 8 or 10), length = 12")},
+		{LL{Lat: 51.95, Lon: -181}, 6, "", fmt.Errorf("invalid longitude, lon = -181")},
+		{LL{Lat: 51.95, Lon: math.NaN()}, 6, "", fmt.Errorf("invalid longitude, lon = NaN")},
+		{LL{Lat: math.NaN(), Lon: 7.53}, 6, "", fmt.Errorf("invalid latitude, lat = NaN")},
+	}
+
+	for _, test := range tests {
+		maidenhead, err := test.ll.ToMaidenhead(test.length)
+		function := fmt.Sprintf("%#v.ToMaidenhead(%d)", test.ll, test.length)
+		got := fmt.Sprintf("%s %v", maidenhead, err)
+		want := fmt.Sprintf("%s %v", test.maidenhead, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMaidenhead_Bounds(t *testing.T) {
+
+	var tests = []struct {
+		maidenhead Maidenhead  // in
+		normalized Maidenhead  // out
+		bbox       BoundingBox // out
+		ll         LL          // out
+		err        error       // out
+	}{
+		// positive tests
+		{"JO", "JO", BoundingBox{South: 50, West: 0, North: 60, East: 20}, LL{Lat: 55, Lon: 10}, nil},
+		{"JO31", "JO31", BoundingBox{South: 51, West: 6, North: 52, East: 8}, LL{Lat: 51.5, Lon: 7}, nil},
+		{"jo31LE", "JO31le", BoundingBox{South: 51.166667, West: 6.916667, North: 51.208333, East: 7}, LL{Lat: 51.1875, Lon: 6.958333}, nil},
+		{"JO31le12", "JO31le12", BoundingBox{South: 51.175, West: 6.925, North: 51.179167, East: 6.933333}, LL{Lat: 51.177083, Lon: 6.929167}, nil},
+		{"JO31LE12AB", "JO31le12ab", BoundingBox{South: 51.175174, West: 6.925, North: 51.175347, East: 6.925347}, LL{Lat: 51.175260, Lon: 6.925174}, nil},
+		// negative tests
+		{"SO31", "", BoundingBox{}, LL{}, fmt.Errorf("invalid locator character 'S' at position 1, locator = SO31")},
+		{"JOAA", "", BoundingBox{}, LL{}, fmt.Errorf("invalid locator character 'A' at position 3, locator = JOAA")},
+		{"JO31ly", "", BoundingBox{}, LL{}, fmt.Errorf("invalid locator character 'y' at position 6, locator = JO31ly")},
+		{"JO31le12ay", "", BoundingBox{}, LL{}, fmt.Errorf("invalid locator character 'y' at position 10, locator = JO31le12ay")},
+		{"JO3", "", BoundingBox{}, LL{}, fmt.Errorf("invalid locator length (2, 4, 6, 8 or 10), locator = JO3")},
+	}
+
+	for _, test := range tests {
+		normalized, err := test.maidenhead.Normalize()
+		function := fmt.Sprintf("%s.Normalize()", test.maidenhead)
+		got := fmt.Sprintf("%s %v", normalized, err)
+		want := fmt.Sprintf("%s %v", test.normalized, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		bbox, err := test.maidenhead.Bounds()
+		function = fmt.Sprintf("%s.Bounds()", test.maidenhead)
+		got = fmt.Sprintf("%s %v", bbox, err)
+		want = fmt.Sprintf("%s %v", test.bbox, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		ll, err := test.maidenhead.ToLL()
+		function = fmt.Sprintf("%s.ToLL()", test.maidenhead)
+		got = fmt.Sprintf("%s %v", ll, err)
+		want = fmt.Sprintf("%s %v", test.ll, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMaidenhead_DistanceTo(t *testing.T) {
+
+	var tests = []struct {
+		from     Maidenhead // in
+		to       Maidenhead // in
+		distance float64    // out (km)
+		bearing  float64    // out
+		err      error      // out
+	}{
+		// positive tests
+		{"JO31le", "FN31pr", 5887.5, 293.1, nil},
+		{"JN58td", "GF15vc", 11418.3, 231.1, nil},
+		{"JO31le", "JO31le", 0, 0, nil},
+		// negative tests
+		{"JO31le", "ZZ99", 0, 0, fmt.Errorf("error <invalid locator character 'Z' at position 1, locator = ZZ99> at to.ToLL(), locator = ZZ99")},
+	}
+
+	for _, test := range tests {
+		distance, err := test.from.DistanceTo(test.to)
+		function := fmt.Sprintf("%s.DistanceTo(%s)", test.from, test.to)
+		got := fmt.Sprintf("%.1f %v", distance/1000, err)
+		want := fmt.Sprintf("%.1f %v", test.distance, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		bearing, err := test.from.BearingTo(test.to)
+		function = fmt.Sprintf("%s.BearingTo(%s)", test.from, test.to)
+		got = fmt.Sprintf("%.1f %v", bearing, err)
+		want = fmt.Sprintf("%.1f %v", test.bearing, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMaidenhead_MGRS(t *testing.T) {
+
+	var tests = []struct {
+		maidenhead Maidenhead // in
+		mgrs       MGRS       // out
+		back       Maidenhead // out
+	}{
+		{"JN58td", "32UPU9525335841", "JN58td"},
+		{"GF15vc", "21HWB7233338222", "GF15vc"},
+		{"JO31sw", "32ULC9974755091", "JO31sw"},
+	}
+
+	for _, test := range tests {
+		mgrs, err := test.maidenhead.ToMGRS(1)
+		function := fmt.Sprintf("%s.ToMGRS(1)", test.maidenhead)
+		got := fmt.Sprintf("%s %v", mgrs, err)
+		want := fmt.Sprintf("%s %v", test.mgrs, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		back, err := mgrs.ToMaidenhead(6)
+		function = fmt.Sprintf("%s.ToMaidenhead(6)", mgrs)
+		got = fmt.Sprintf("%s %v", back, err)
+		want = fmt.Sprintf("%s %v", test.back, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleMaidenhead_DistanceTo() {
+
+	home := Maidenhead("JO31le")
+	distance, err := home.DistanceTo("FN31pr")
+	if err != nil {
+		log.Printf("error <%v> at home.DistanceTo()", err)
+		return
+	}
+	bearing, err := home.BearingTo("FN31pr")
+	if err != nil {
+		log.Printf("error <%v> at home.BearingTo()", err)
+		return
+	}
+	fmt.Printf("%.0f km %.0f°\n", distance/1000, bearing)
+
+	// Output:
+	// 5887 km 293°
+}
@@ -0,0 +1,380 @@
+/*
+Purpose:
+- MGRS/UTMREF cell geometry: center, corners and polygon in Lon Lat
+
+Description:
+- A MGRS reference denotes a square cell (e.g. "32UMV1234567890" = 1 m, "32UMV" = 100 km), mgrs.ToLL()
+  returns only the south-west corner of this cell.
+- Corners returns the four grid corners of the (unclipped) square.
+- Polygon returns the cell clipped at the boundaries of its grid zone (UTM zone and latitude band,
+  including the Norway and Svalbard exceptions, or the polar UPS region). 100 km squares at zone and
+  band boundaries are partial, their polygon follows the curved zone and band boundaries.
+- Center returns the centroid (in grid coordinates) of the clipped cell, for complete cells this is
+  the center of the square.
+
+Remarks:
+- Clipping works in grid coordinates (Sutherland-Hodgman): the part of the grid zone around the cell is
+  densified (adaptive, deviation less than 1e-6 of the cell size) and clipped by the square.
+- Polygons are closed rings (counterclockwise, starting south-west), straight grid edges are represented
+  by their end points only.
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+)
+
+// mgrsCell holds the square of a MGRS reference in grid coordinates together with its grid zone
+type mgrsCell struct {
+	x0, y0  float64     // south-west corner (easting, northing)
+	size    float64     // edge length in meters
+	region  BoundingBox // grid zone in Lon Lat
+	forward func(ll LL) (float64, float64)
+	inverse func(x, y float64) (LL, error)
+}
+
+/*
+Corners returns the four grid corners of the MGRS cell (south-west, south-east, north-east, north-west).
+The corners are not clipped at the boundaries of the grid zone.
+*/
+func (mgrs MGRS) Corners() ([4]LL, error) {
+
+	cell, err := mgrs.cell()
+	if err != nil {
+		return [4]LL{}, err
+	}
+
+	corners := [4]LL{}
+	for i, offset := range [4][2]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}} {
+		corners[i], err = cell.inverse(cell.x0+offset[0]*cell.size, cell.y0+offset[1]*cell.size)
+		if err != nil {
+			return [4]LL{}, fmt.Errorf("error <%w> at cell.inverse(), mgrs = %s", err, mgrs)
+		}
+	}
+
+	return corners, nil
+}
+
+/*
+Polygon returns the MGRS cell clipped at the boundaries of its grid zone as closed ring
+(counterclockwise, starting south-west).
+*/
+func (mgrs MGRS) Polygon() ([]LL, error) {
+
+	cell, err := mgrs.cell()
+	if err != nil {
+		return nil, err
+	}
+
+	ring, err := cell.clip()
+	if err != nil {
+		return nil, fmt.Errorf("error <%w> at cell.clip(), mgrs = %s", err, mgrs)
+	}
+
+	polygon := []LL{}
+	for _, p := range append(ring, ring[0]) {
+		ll, err := cell.inverse(p[0], p[1])
+		if err != nil {
+			return nil, fmt.Errorf("error <%w> at cell.inverse(), mgrs = %s", err, mgrs)
+		}
+		polygon = append(polygon, ll)
+	}
+
+	return polygon, nil
+}
+
+/*
+Center returns the center of the MGRS cell (centroid of the cell clipped at the boundaries of its grid zone).
+*/
+func (mgrs MGRS) Center() (LL, error) {
+
+	cell, err := mgrs.cell()
+	if err != nil {
+		return LL{}, err
+	}
+
+	ring, err := cell.clip()
+	if err != nil {
+		return LL{}, fmt.Errorf("error <%w> at cell.clip(), mgrs = %s", err, mgrs)
+	}
+
+	// centroid relative to the south-west corner (complete cells: center of the square)
+	x, y := cell.size/2, cell.size/2
+	if len(ring) != 4 || math.Abs(ringArea(ring)-cell.size*cell.size) > 1e-9*cell.size*cell.size {
+		area, cx, cy := 0.0, 0.0, 0.0
+		for i := range ring {
+			ax, ay := ring[i][0]-cell.x0, ring[i][1]-cell.y0
+			bx, by := ring[(i+1)%len(ring)][0]-cell.x0, ring[(i+1)%len(ring)][1]-cell.y0
+			cross := ax*by - bx*ay
+			area += cross
+			cx += (ax + bx) * cross
+			cy += (ay + by) * cross
+		}
+		x, y = cx/(3*area), cy/(3*area)
+	}
+
+	ll, err := cell.inverse(cell.x0+x, cell.y0+y)
+	if err != nil {
+		return LL{}, fmt.Errorf("error <%w> at cell.inverse(), mgrs = %s", err, mgrs)
+	}
+
+	return ll, nil
+}
+
+/*
+cell returns the square of the MGRS reference in grid coordinates (UTM or UPS) and its grid zone.
+*/
+func (mgrs MGRS) cell() (mgrsCell, error) {
+
+	cell := mgrsCell{}
+
+	if mgrs.isPolar() {
+		ups, accuracy, err := mgrs.ToUPS()
+		if err != nil {
+			return mgrsCell{}, fmt.Errorf("error <%w> at mgrs.ToUPS(), mgrs = %s", err, mgrs)
+		}
+
+		cell.x0, cell.y0, cell.size = ups.Easting, ups.Northing, float64(accuracy)
+		switch ups.ZoneLetter {
+		case 'A':
+			cell.region = BoundingBox{South: -90, West: -180, North: -80, East: 0}
+		case 'B':
+			cell.region = BoundingBox{South: -90, West: 0, North: -80, East: 180}
+		case 'Y':
+			cell.region = BoundingBox{South: 84, West: -180, North: 90, East: 0}
+		case 'Z':
+			cell.region = BoundingBox{South: 84, West: 0, North: 90, East: 180}
+		}
+		cell.forward = func(ll LL) (float64, float64) {
+			p := ll.ToUPS()
+			return p.Easting, p.Northing
+		}
+		cell.inverse = func(x, y float64) (LL, error) {
+			return UPS{ZoneLetter: ups.ZoneLetter, Easting: x, Northing: y}.ToLL()
+		}
+	} else {
+		utm, accuracy, err := mgrs.ToUTM()
+		if err != nil {
+			return mgrsCell{}, fmt.Errorf("error <%w> at mgrs.ToUTM(), mgrs = %s", err, mgrs)
+		}
+
+		cell.x0, cell.y0, cell.size = utm.Easting, utm.Northing, float64(accuracy)
+		south, north, west, east, err := gzdBounds(utm.ZoneNumber, utm.ZoneLetter)
+		if err != nil {
+			return mgrsCell{}, fmt.Errorf("error <%w> at gzdBounds(), mgrs = %s", err, mgrs)
+		}
+		cell.region = BoundingBox{South: south, West: west, North: north, East: east}
+
+		tm := utmProjection(EllipsoidWGS84, Krueger, utm.ZoneNumber)
+		if utm.Hemisphere() == SouthernHemisphere {
+			tm.falseNorthing = 10000000.0
+		}
+		cell.forward = func(ll LL) (float64, float64) {
+			return tm.forward(ll.Lat, ll.Lon)
+		}
+		cell.inverse = func(x, y float64) (LL, error) {
+			return UTM{ZoneNumber: utm.ZoneNumber, ZoneLetter: utm.ZoneLetter, Easting: x, Northing: y}.ToLL()
+		}
+	}
+
+	// 100k square without digits
+	if cell.size == 0 {
+		cell.size = 100000
+	}
+
+	return cell, nil
+}
+
+/*
+clip returns the cell clipped at the boundaries of its grid zone as ring in grid coordinates
+(counterclockwise, starting south-west, not closed).
+*/
+func (cell mgrsCell) clip() ([][2]float64, error) {
+
+	area, err := cell.area()
+	if err != nil {
+		return nil, err
+	}
+
+	// grid zone boundary around the cell, densified in grid coordinates
+	tolerance := cell.size * 1e-6
+	corners := densifyBounds(area, 1)
+	subject := [][2]float64{}
+	for i := range corners {
+		subject = cell.densify(subject, corners[i], corners[(i+1)%len(corners)], tolerance, 0)
+	}
+
+	// Sutherland-Hodgman: clip by the four edges of the square (west, east, south, north)
+	minX, minY := cell.x0, cell.y0
+	maxX, maxY := cell.x0+cell.size, cell.y0+cell.size
+	ring := clipRing(subject, 0, minX, false)
+	ring = clipRing(ring, 0, maxX, true)
+	ring = clipRing(ring, 1, minY, false)
+	ring = clipRing(ring, 1, maxY, true)
+
+	ring = simplifyRing(ring, tolerance*1e-3)
+	if len(ring) < 3 || ringArea(ring) == 0 {
+		return nil, fmt.Errorf("cell outside of grid zone, region = %s", cell.region)
+	}
+	if ringArea(ring) < 0 {
+		for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+			ring[i], ring[j] = ring[j], ring[i]
+		}
+	}
+
+	// start with the south-west vertex
+	start := 0
+	for i, p := range ring {
+		if p[0]+p[1] < ring[start][0]+ring[start][1] {
+			start = i
+		}
+	}
+
+	return append(ring[start:], ring[:start]...), nil
+}
+
+/*
+area returns the part of the grid zone (Lon Lat) around the cell, enlarged by 10 percent.
+The complete grid zone is too large for an accurate densification of small cells.
+*/
+func (cell mgrsCell) area() (BoundingBox, error) {
+
+	const steps = 8
+	bbox := BoundingBox{South: 90, West: 180, North: -90, East: -180}
+	for i := 0; i < 4*steps; i++ {
+		f := float64(i%steps) / steps
+		offset := [4][2]float64{{f, 0}, {1, f}, {1 - f, 1}, {0, 1 - f}}[i/steps]
+		ll, err := cell.inverse(cell.x0+offset[0]*cell.size, cell.y0+offset[1]*cell.size)
+		if err != nil {
+			return BoundingBox{}, fmt.Errorf("error <%w> at cell.inverse()", err)
+		}
+		bbox.South = math.Min(bbox.South, ll.Lat)
+		bbox.North = math.Max(bbox.North, ll.Lat)
+		bbox.West = math.Min(bbox.West, ll.Lon)
+		bbox.East = math.Max(bbox.East, ll.Lon)
+	}
+
+	// cell around a pole (UPS): all longitudes of the region
+	poleX, poleY := cell.forward(LL{Lat: math.Copysign(90, cell.region.North), Lon: 0})
+	if poleX >= cell.x0 && poleX <= cell.x0+cell.size && poleY >= cell.y0 && poleY <= cell.y0+cell.size {
+		bbox.South = math.Min(bbox.South, -90)
+		bbox.North = math.Max(bbox.North, 90)
+		bbox.West, bbox.East = -180, 180
+	}
+
+	latMargin := (bbox.North-bbox.South)/10 + gzdTolerance
+	lonMargin := (bbox.East-bbox.West)/10 + gzdTolerance
+
+	area := BoundingBox{}
+	area.South = math.Max(cell.region.South, bbox.South-latMargin)
+	area.North = math.Min(cell.region.North, bbox.North+latMargin)
+	area.West = math.Max(cell.region.West, bbox.West-lonMargin)
+	area.East = math.Min(cell.region.East, bbox.East+lonMargin)
+	if area.South >= area.North || area.West >= area.East {
+		return BoundingBox{}, fmt.Errorf("cell outside of grid zone, region = %s", cell.region)
+	}
+
+	return area, nil
+}
+
+/*
+densify appends the Lon Lat line from a to b (without b) in grid coordinates to the ring. The line is
+bisected until the grid midpoint deviates less than the tolerance from the chord.
+*/
+func (cell mgrsCell) densify(ring [][2]float64, a, b LL, tolerance float64, depth int) [][2]float64 {
+
+	ax, ay := cell.forward(a)
+	bx, by := cell.forward(b)
+	mid := LL{Lat: (a.Lat + b.Lat) / 2, Lon: (a.Lon + b.Lon) / 2}
+	mx, my := cell.forward(mid)
+
+	deviation := math.Hypot(mx-(ax+bx)/2, my-(ay+by)/2)
+	if depth < 4 || (deviation > tolerance && depth < 24) {
+		ring = cell.densify(ring, a, mid, tolerance, depth+1)
+		return cell.densify(ring, mid, b, tolerance, depth+1)
+	}
+
+	return append(ring, [2]float64{ax, ay})
+}
+
+/*
+clipRing clips the ring (not closed) at an axis parallel line (Sutherland-Hodgman).
+axis holds 0 for x (easting) or 1 for y (northing).
+value holds the position of the line.
+upper holds whether the inside is below (true) or above (false) the line.
+*/
+func clipRing(ring [][2]float64, axis int, value float64, upper bool) [][2]float64 {
+
+	inside := func(p [2]float64) bool {
+		if upper {
+			return p[axis] <= value
+		}
+		return p[axis] >= value
+	}
+	intersection := func(a, b [2]float64) [2]float64 {
+		t := (value - a[axis]) / (b[axis] - a[axis])
+		p := [2]float64{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])}
+		p[axis] = value
+		return p
+	}
+
+	result := [][2]float64{}
+	for i := range ring {
+		current := ring[i]
+		previous := ring[(i+len(ring)-1)%len(ring)]
+		switch {
+		case inside(current) && !inside(previous):
+			result = append(result, intersection(previous, current), current)
+		case inside(current):
+			result = append(result, current)
+		case inside(previous):
+			result = append(result, intersection(previous, current))
+		}
+	}
+
+	return result
+}
+
+/*
+simplifyRing removes duplicate points and points on straight axis parallel edges (not closed ring).
+tolerance holds the distance below which two points are equal.
+*/
+func simplifyRing(ring [][2]float64, tolerance float64) [][2]float64 {
+
+	for changed := true; changed && len(ring) >= 3; {
+		changed = false
+		for i := 0; i < len(ring) && len(ring) >= 3; i++ {
+			previous := ring[(i+len(ring)-1)%len(ring)]
+			current := ring[i]
+			next := ring[(i+1)%len(ring)]
+			duplicate := math.Hypot(current[0]-previous[0], current[1]-previous[1]) <= tolerance
+			straight := (previous[0] == current[0] && current[0] == next[0]) ||
+				(previous[1] == current[1] && current[1] == next[1])
+			if duplicate || straight {
+				ring = append(ring[:i:i], ring[i+1:]...)
+				changed = true
+				i--
+			}
+		}
+	}
+
+	return ring
+}
+
+/*
+ringArea returns the signed area of the ring (not closed, positive for counterclockwise rings).
+The area is calculated relative to the first point (large grid coordinates).
+*/
+func ringArea(ring [][2]float64) float64 {
+
+	area := 0.0
+	for i := range ring {
+		ax, ay := ring[i][0]-ring[0][0], ring[i][1]-ring[0][1]
+		bx, by := ring[(i+1)%len(ring)][0]-ring[0][0], ring[(i+1)%len(ring)][1]-ring[0][1]
+		area += ax*by - bx*ay
+	}
+
+	return area / 2
+}
@@ -0,0 +1,158 @@
+/*
+Purpose:
+- MGRS/UTMREF cell geometry: center, corners and polygon in Lon Lat
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"testing"
+)
+
+func TestMGRS_Center(t *testing.T) {
+
+	var tests = []struct {
+		mgrs   MGRS  // in
+		center LL    // out
+		err    error // out
+	}{
+		// positive tests
+		{"32UMV1234567890", LL{Lat: 49.357421, Lon: 7.792919}, nil},
+		{"32UMV12346789", LL{Lat: 49.357462, Lon: 7.792911}, nil},
+		{"32ulv", LL{Lat: 49.184448, Lon: 6.941534}, nil},
+		{"31UGT", LL{Lat: 51.816627, Lon: 5.950904}, nil},   // partial, zone boundary 6°E
+		{"33UUP", LL{Lat: 48.367848, Lon: 12.978262}, nil},  // partial, band boundary 48°N
+		{"32VJR", LL{Lat: 63.117229, Lon: 3.023451}, nil},   // partial, Norway exception
+		{"33XUL", LL{Lat: 81.142733, Lon: 9.086042}, nil},   // partial, Svalbard exception
+		{"34HBH", LL{Lat: -33.860496, Lon: 18.418823}, nil}, // partial, southern hemisphere
+		{"ZGC", LL{Lat: 84.350603, Lon: 45}, nil},           // partial, UPS boundary 84°N
+		{"YZG", LL{Lat: 89.363110, Lon: -45}, nil},          // north pole in the corner
+		{"31VDH", LL{Lat: 60.882043, Lon: 2.078936}, nil},   // east edge on 3°E, complete
+		// negative tests
+		{"31VEH", LL{}, fmt.Errorf("error <square not in zone, gzd = 31V (lat 56..64, lon 0..3), utm = 31V 500000 6700000, mgrs = 31VEH> at mgrs.ToUTM(), mgrs = 31VEH")}, // west edge on 3°E, no area in 31V
+		{"32UXX", LL{}, fmt.Errorf("error <invalid 100k column letter 'X' for zone 32U at position 4, mgrs = 32UXX> at mgrs.ToUTM(), mgrs = 32UXX")},
+	}
+
+	for _, test := range tests {
+		center, err := test.mgrs.Center()
+		function := fmt.Sprintf("MGRS(%q).Center()", test.mgrs)
+		got := fmt.Sprintf("%s %v", center, err)
+		want := fmt.Sprintf("%s %v", test.center, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMGRS_Corners(t *testing.T) {
+
+	var tests = []struct {
+		mgrs    MGRS   // in
+		corners string // out
+		err     error  // out
+	}{
+		// positive tests
+		{"32UMV1234567890", "[49.357417 7.792912 49.357417 7.792926 49.357426 7.792926 49.357426 7.792912]", nil},
+		{"32UMV", "[48.744984 7.639721 48.753013 9.000000 49.652543 9.000000 49.644257 7.614831]", nil},
+		{"31UGT", "[51.415884 5.876292 51.371839 7.310792 52.268365 7.397379 52.313844 5.934190]", nil}, // not clipped
+		{"ZAG0000099005", "[89.991038 0.000000 89.991038 0.057584 89.991047 0.057642 89.991047 0.000000]", nil},
+		// negative tests
+		{"32XNJ", "[0.000000 0.000000 0.000000 0.000000 0.000000 0.000000 0.000000 0.000000]", fmt.Errorf("error <square not in zone, grid zone does not exist (Svalbard exception), gzd = 32X, mgrs = 32XNJ> at mgrs.ToUTM(), mgrs = 32XNJ")},
+	}
+
+	for _, test := range tests {
+		corners, err := test.mgrs.Corners()
+		function := fmt.Sprintf("MGRS(%q).Corners()", test.mgrs)
+		got := fmt.Sprintf("%v %v", corners, err)
+		want := fmt.Sprintf("%s %v", test.corners, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMGRS_Polygon(t *testing.T) {
+
+	var tests = []struct {
+		mgrs   MGRS   // in
+		points int    // out
+		first  string // out
+		err    error  // out
+	}{
+		// positive tests
+		{"32UMV1234567890", 5, "49.357417 7.792912", nil},
+		{"32UMV", 5, "48.744984 7.639721", nil},
+		{"31UGT", 18, "51.415884 5.876292", nil},  // partial, zone boundary 6°E
+		{"33UUP", 57, "48.000000 12.318772", nil}, // partial, band boundary 48°N
+		{"32VJR", 9, "63.002063 3.000001", nil},   // partial, Norway exception
+		{"33XUL", 10, "81.012435 9.000000", nil},  // partial, Svalbard exception
+		{"ZGC", 127, "84.237323 38.659808", nil},  // partial, UPS boundary 84°N
+		{"YZG", 5, "88.726257 -45.000000", nil},
+		{"31VDH", 5, "60.423899 1.183729", nil}, // east edge on 3°E, complete
+		// negative tests
+		{"31VEH", 0, "0.000000 0.000000", fmt.Errorf("error <square not in zone, gzd = 31V (lat 56..64, lon 0..3), utm = 31V 500000 6700000, mgrs = 31VEH> at mgrs.ToUTM(), mgrs = 31VEH")}, // west edge on 3°E, no area in 31V
+	}
+
+	for _, test := range tests {
+		polygon, err := test.mgrs.Polygon()
+		function := fmt.Sprintf("MGRS(%q).Polygon()", test.mgrs)
+		first := LL{}
+		if len(polygon) > 0 {
+			first = polygon[0]
+			if polygon[len(polygon)-1] != first {
+				t.Errorf("\n%s -> ring not closed\n", function)
+			}
+		}
+		got := fmt.Sprintf("%d %s %v", len(polygon), first, err)
+		want := fmt.Sprintf("%d %s %v", test.points, test.first, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMGRS_PolygonInZone(t *testing.T) {
+
+	// all vertices of partial cells lie inside of the grid zone (tolerance about 0.1 m)
+	const tolerance = 2e-6
+	for _, mgrs := range []MGRS{"31UGT", "32UKB", "33UUP", "31NAA", "32VJH", "32VPS", "33XTA", "33XVP", "34HBH"} {
+		polygon, err := mgrs.Polygon()
+		if err != nil {
+			t.Fatalf("error <%v> at mgrs.Polygon(), mgrs = %s", err, mgrs)
+		}
+		c, _ := ParseMGRS(string(mgrs))
+		south, north, west, east, _ := gzdBounds(c.ZoneNumber, c.ZoneLetter)
+		for _, ll := range polygon {
+			if ll.Lat < south-tolerance || ll.Lat > north+tolerance || ll.Lon < west-tolerance || ll.Lon > east+tolerance {
+				t.Errorf("\nMGRS(%q).Polygon() -> vertex %s outside of grid zone %d%c\n", mgrs, ll, c.ZoneNumber, c.ZoneLetter)
+			}
+		}
+	}
+}
+
+func ExampleMGRS_Center() {
+
+	// MGRS references denote cells, mgrs.ToLL() returns the south-west corner
+	mgrs := MGRS("32UMV12346789")
+	sw, _, err := mgrs.ToLL()
+	if err != nil {
+		log.Printf("error <%v> at mgrs.ToLL()", err)
+		return
+	}
+	center, err := mgrs.Center()
+	if err != nil {
+		log.Printf("error <%v> at mgrs.Center()", err)
+		return
+	}
+	fmt.Println(sw)
+	fmt.Println(center)
+
+	// Output:
+	// 49.357416 7.792843
+	// 49.357462 7.792911
+}
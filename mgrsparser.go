@@ -0,0 +1,246 @@
+/*
+Purpose:
+- MGRS/UTMREF parser
+
+Description:
+- Lenient parser for human MGRS/UTMREF input ("32U MV 12345 67890", "32umv1234567890 ", "04QFJ1234567890").
+  Errors report the exact character position, the result is returned as structured components.
+*/
+
+package coco
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MGRSComponents defines the structured components of a MGRS/UTMREF reference
+type MGRSComponents struct {
+	ZoneNumber int    // 1-60, 0 for polar (UPS) references
+	ZoneLetter byte   // latitude band letter (C-X) or polar letter (A, B, Y, Z)
+	ID100k     string // 100k square identification (two letters)
+	Easting    string // easting digits
+	Northing   string // northing digits
+	Precision  int    // number of digits per easting/northing (0-5)
+}
+
+// MGRSParseError defines an error at a character position of a MGRS/UTMREF string
+type MGRSParseError struct {
+	Input    string // string to parse
+	Position int    // character position (1 = first character)
+	Message  string // error description
+}
+
+/*
+Error returns the description of the parse error.
+*/
+func (e *MGRSParseError) Error() string {
+
+	return fmt.Sprintf("%s at position %d, mgrs = %s", e.Message, e.Position, e.Input)
+}
+
+/*
+GZD returns the grid zone designation (e.g. "32U" or "Z").
+*/
+func (c MGRSComponents) GZD() string {
+
+	if c.ZoneNumber == 0 {
+		return string(c.ZoneLetter)
+	}
+
+	return fmt.Sprintf("%d%c", c.ZoneNumber, c.ZoneLetter)
+}
+
+/*
+String returns the MGRS/UTMREF reference in canonical form (e.g. "32ULC9897356497").
+*/
+func (c MGRSComponents) String() string {
+
+	return c.GZD() + c.ID100k + c.Easting + c.Northing
+}
+
+/*
+MGRS returns the MGRS/UTMREF reference in canonical form.
+*/
+func (c MGRSComponents) MGRS() MGRS {
+
+	return MGRS(c.String())
+}
+
+/*
+Parse parses the MGRS/UTMREF string (see ParseMGRS).
+*/
+func (mgrs MGRS) Parse() (MGRSComponents, error) {
+
+	return ParseMGRS(string(mgrs))
+}
+
+/*
+ParseMGRS parses a MGRS/UTMREF string into its components.
+Whitespace between the components, lower case letters and leading zeros of the zone number are accepted.
+Easting and northing digits may be given as one group (split in the middle) or as two groups of equal length.
+Errors are returned as *MGRSParseError with the position of the offending character.
+s holds the MGRS/UTMREF string.
+*/
+func ParseMGRS(s string) (MGRSComponents, error) {
+
+	input := []rune(s)
+	i := 0
+
+	fail := func(pos int, format string, a ...interface{}) (MGRSComponents, error) {
+		return MGRSComponents{}, &MGRSParseError{Input: s, Position: pos + 1, Message: fmt.Sprintf(format, a...)}
+	}
+	skipSpace := func() {
+		for i < len(input) && unicode.IsSpace(input[i]) {
+			i++
+		}
+	}
+	isDigit := func(r rune) bool {
+		return r >= '0' && r <= '9'
+	}
+
+	c := MGRSComponents{}
+
+	skipSpace()
+	if i == len(input) {
+		return fail(i, "empty mgrs string")
+	}
+
+	// zone number (none for polar references)
+	start := i
+	for i < len(input) && isDigit(input[i]) {
+		i++
+	}
+	zoneDigits := string(input[start:i])
+	if len(zoneDigits) > 2 {
+		return fail(start+2, "too many zone number digits")
+	}
+	skipSpace()
+
+	// zone letter
+	if i == len(input) {
+		return fail(i, "missing zone letter")
+	}
+	letter := unicode.ToUpper(input[i])
+	if zoneDigits == "" {
+		if !(letter < unicode.MaxASCII && isPolarLetter(byte(letter))) {
+			if letter >= 'A' && letter <= 'Z' {
+				return fail(i, "missing zone number")
+			}
+			return fail(i, "unexpected character %q", input[i])
+		}
+	} else {
+		zone, _ := strconv.Atoi(zoneDigits)
+		if zone < 1 || zone > 60 {
+			return fail(start, "invalid zone number %d", zone)
+		}
+		if !strings.ContainsRune(bandLetters, letter) {
+			return fail(i, "invalid latitude band letter %q", input[i])
+		}
+		c.ZoneNumber = zone
+	}
+	c.ZoneLetter = byte(letter)
+	i++
+	skipSpace()
+
+	// 100k square identification
+	columns, rows := letters100kForZone(c.ZoneNumber, c.ZoneLetter)
+	for k, valid := range []string{columns, rows} {
+		if i == len(input) {
+			return fail(i, "missing 100k square letter")
+		}
+		ch := unicode.ToUpper(input[i])
+		if !strings.ContainsRune(valid, ch) {
+			kind := "column"
+			if k == 1 {
+				kind = "row"
+			}
+			return fail(i, "invalid 100k %s letter %q for zone %s", kind, input[i], c.GZD())
+		}
+		c.ID100k += string(ch)
+		i++
+	}
+	skipSpace()
+
+	// easting and northing digits (one or two groups)
+	type digitGroup struct {
+		digits string
+		pos    int
+	}
+	groups := []digitGroup{}
+	for i < len(input) {
+		start := i
+		for i < len(input) && isDigit(input[i]) {
+			i++
+		}
+		if i == start {
+			return fail(i, "unexpected character %q", input[i])
+		}
+		if len(groups) == 2 {
+			return fail(start, "unexpected third group of digits")
+		}
+		groups = append(groups, digitGroup{digits: string(input[start:i]), pos: start})
+		skipSpace()
+	}
+
+	switch len(groups) {
+	case 1:
+		digits := groups[0].digits
+		if len(digits)%2 != 0 {
+			return fail(groups[0].pos+len(digits)-1, "uneven number of digits (%d)", len(digits))
+		}
+		c.Easting = digits[:len(digits)/2]
+		c.Northing = digits[len(digits)/2:]
+	case 2:
+		if len(groups[0].digits) != len(groups[1].digits) {
+			return fail(groups[1].pos, "number of easting and northing digits differ (%d, %d)", len(groups[0].digits), len(groups[1].digits))
+		}
+		c.Easting = groups[0].digits
+		c.Northing = groups[1].digits
+	}
+
+	c.Precision = len(c.Easting)
+	if c.Precision > 5 {
+		return fail(groups[0].pos+5, "too many digits (max. 5 per easting and northing)")
+	}
+
+	return c, nil
+}
+
+/*
+letters100kForZone returns the valid 100k column and row letters for the given grid zone.
+*/
+func letters100kForZone(zoneNumber int, zoneLetter byte) (string, string) {
+
+	if zoneNumber == 0 {
+		return upsColumnLetters[zoneLetter], upsRowLetters[zoneLetter == 'Y' || zoneLetter == 'Z']
+	}
+
+	// column letters: set 1 and 4 = A-H, set 2 and 5 = J-R, set 3 and 6 = S-Z
+	columns := []string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+	set := get100kSetForZone(zoneNumber)
+
+	return columns[(set-1)%3], "ABCDEFGHJKLMNPQRSTUV"
+}
+
+/*
+values returns the easting and northing (in meters) within the 100k square and the accuracy (in meters) of the digits.
+*/
+func (c MGRSComponents) values() (float64, float64, int) {
+
+	if c.Precision == 0 {
+		return 0, 0, 0
+	}
+
+	accuracy := 100000
+	for k := 0; k < c.Precision; k++ {
+		accuracy /= 10
+	}
+
+	easting, _ := strconv.Atoi(c.Easting)
+	northing, _ := strconv.Atoi(c.Northing)
+
+	return float64(easting * accuracy), float64(northing * accuracy), accuracy
+}
@@ -0,0 +1,106 @@
+/*
+Purpose:
+- MGRS/UTMREF parser
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"testing"
+)
+
+func TestParseMGRS(t *testing.T) {
+
+	var tests = []struct {
+		s          string         // in
+		components MGRSComponents // out
+		err        error          // out
+	}{
+		// positive tests
+		{"32ULC9897356497", MGRSComponents{32, 'U', "LC", "98973", "56497", 5}, nil},
+		{"32U MV 12345 67890", MGRSComponents{32, 'U', "MV", "12345", "67890", 5}, nil},
+		{"32umv1234567890 ", MGRSComponents{32, 'U', "MV", "12345", "67890", 5}, nil},
+		{" 04QFJ 1234 5678", MGRSComponents{4, 'Q', "FJ", "1234", "5678", 4}, nil},
+		{"4Q FJ", MGRSComponents{4, 'Q', "FJ", "", "", 0}, nil},
+		{"33 U XP 0 4", MGRSComponents{33, 'U', "XP", "0", "4", 1}, nil},
+		{"ZAH 00000 00000", MGRSComponents{0, 'Z', "AH", "00000", "00000", 5}, nil},
+		{"bAN12", MGRSComponents{0, 'B', "AN", "1", "2", 1}, nil},
+		// negative tests
+		{"", MGRSComponents{}, fmt.Errorf("empty mgrs string at position 1, mgrs = ")},
+		{"   ", MGRSComponents{}, fmt.Errorf("empty mgrs string at position 4, mgrs =    ")},
+		{"321U", MGRSComponents{}, fmt.Errorf("too many zone number digits at position 3, mgrs = 321U")},
+		{"61ULC", MGRSComponents{}, fmt.Errorf("invalid zone number 61 at position 1, mgrs = 61ULC")},
+		{"00ULC", MGRSComponents{}, fmt.Errorf("invalid zone number 0 at position 1, mgrs = 00ULC")},
+		{"32", MGRSComponents{}, fmt.Errorf("missing zone letter at position 3, mgrs = 32")},
+		{"32ILC", MGRSComponents{}, fmt.Errorf("invalid latitude band letter 'I' at position 3, mgrs = 32ILC")},
+		{"32ZLC", MGRSComponents{}, fmt.Errorf("invalid latitude band letter 'Z' at position 3, mgrs = 32ZLC")},
+		{"ULC98", MGRSComponents{}, fmt.Errorf("missing zone number at position 1, mgrs = ULC98")},
+		{"#32ULC", MGRSComponents{}, fmt.Errorf("unexpected character '#' at position 1, mgrs = #32ULC")},
+		{"32U", MGRSComponents{}, fmt.Errorf("missing 100k square letter at position 4, mgrs = 32U")},
+		{"32U L", MGRSComponents{}, fmt.Errorf("missing 100k square letter at position 6, mgrs = 32U L")},
+		{"32UAC98", MGRSComponents{}, fmt.Errorf("invalid 100k column letter 'A' for zone 32U at position 4, mgrs = 32UAC98")},
+		{"32ULW98", MGRSComponents{}, fmt.Errorf("invalid 100k row letter 'W' for zone 32U at position 5, mgrs = 32ULW98")},
+		{"32ULC9897356497CORRUPT", MGRSComponents{}, fmt.Errorf("unexpected character 'C' at position 16, mgrs = 32ULC9897356497CORRUPT")},
+		{"32ULC989735649", MGRSComponents{}, fmt.Errorf("uneven number of digits (9) at position 14, mgrs = 32ULC989735649")},
+		{"32ULC 98973 5649", MGRSComponents{}, fmt.Errorf("number of easting and northing digits differ (5, 4) at position 13, mgrs = 32ULC 98973 5649")},
+		{"32ULC 98 97 35", MGRSComponents{}, fmt.Errorf("unexpected third group of digits at position 13, mgrs = 32ULC 98 97 35")},
+		{"32ULC989735564970", MGRSComponents{}, fmt.Errorf("too many digits (max. 5 per easting and northing) at position 11, mgrs = 32ULC989735564970")},
+	}
+
+	for _, test := range tests {
+		components, err := ParseMGRS(test.s)
+		function := fmt.Sprintf("ParseMGRS(%q)", test.s)
+		got := fmt.Sprintf("%#v %v", components, err)
+		want := fmt.Sprintf("%#v %v", test.components, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMGRS_ToUTM_Lenient(t *testing.T) {
+
+	var tests = []struct {
+		mgrs     MGRS // in
+		utm      UTM  // out
+		accuracy int  // out
+	}{
+		// positive tests
+		{"32U LC 98973 56497", UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973, Northing: 5756497}, 1},
+		{"32ulc9897356497 ", UTM{ZoneNumber: 32, ZoneLetter: 'U', Easting: 398973, Northing: 5756497}, 1},
+		{"04QFJ1234567890", UTM{ZoneNumber: 4, ZoneLetter: 'Q', Easting: 612345, Northing: 2367890}, 1},
+		{"4Q FJ 123 678", UTM{ZoneNumber: 4, ZoneLetter: 'Q', Easting: 612300, Northing: 2367800}, 100},
+	}
+
+	for _, test := range tests {
+		utm, accuracy, err := test.mgrs.ToUTM()
+		function := fmt.Sprintf("mgrs = %s, ToUTM()", test.mgrs)
+		got := fmt.Sprintf("%s %d %v", utm, accuracy, err)
+		want := fmt.Sprintf("%s %d %v", test.utm, test.accuracy, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleParseMGRS() {
+
+	components, err := ParseMGRS("32u mv 12345 67890")
+	if err != nil {
+		log.Fatalf("error <%v> at ParseMGRS()", err)
+	}
+	fmt.Printf("%s %s %s %s (precision %d) -> %s\n", components.GZD(), components.ID100k, components.Easting, components.Northing, components.Precision, components)
+
+	_, err = ParseMGRS("32UMV1234567B90")
+	if perr, ok := err.(*MGRSParseError); ok {
+		fmt.Printf("position %d: %s\n", perr.Position, perr.Message)
+	}
+	// Output:
+	// 32U MV 12345 67890 (precision 5) -> 32UMV1234567890
+	// position 13: unexpected character 'B'
+}
@@ -0,0 +1,181 @@
+/*
+Purpose:
+- MGRS/UTMREF grid zone validation
+
+Description:
+- Checks that a 100k square (or the cell given by the digits) really exists in the stated
+  grid zone designation (e.g. "32U"), including the Norway and Svalbard exceptions.
+
+Remarks:
+- The 100k letters repeat every 2000 km (northing) and every three zones (easting). Without this
+  check, a letter pair not existing in the grid zone is silently decoded to a wrong position.
+- The grid zones 32X, 34X and 36X do not exist (Svalbard exception).
+*/
+
+package coco
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrSquareNotInZone is returned if a MGRS 100k square (or cell) does not exist in the stated grid zone.
+var ErrSquareNotInZone = errors.New("square not in zone")
+
+// gzdTolerance defines the tolerance (in degrees) for the grid zone boundaries (about 1 mm)
+const gzdTolerance = 1e-8
+
+/*
+gzdBounds returns the latitude and longitude bounds (south, north, west, east) of a UTM grid zone
+designation (including the Norway and Svalbard exceptions).
+zoneNumber holds the zone number (1-60).
+zoneLetter holds the latitude band letter (C-X).
+*/
+func gzdBounds(zoneNumber int, zoneLetter byte) (float64, float64, float64, float64, error) {
+
+	index := strings.IndexByte(bandLetters, zoneLetter)
+	if zoneNumber < 1 || zoneNumber > 60 || index < 0 {
+		return 0, 0, 0, 0, fmt.Errorf("invalid grid zone designation, gzd = %d%c", zoneNumber, zoneLetter)
+	}
+
+	south := float64(index)*8 - 80
+	north := south + 8
+	west := float64(zoneNumber-1)*6 - 180
+	east := west + 6
+
+	switch zoneLetter {
+	case 'V':
+		// Norway exception: 32V is extended westwards to 3°E
+		switch zoneNumber {
+		case 31:
+			east = 3
+		case 32:
+			west = 3
+		}
+	case 'X':
+		// Svalbard exception: 31X, 33X, 35X and 37X are extended, 32X, 34X and 36X do not exist
+		north = 84
+		switch zoneNumber {
+		case 31:
+			east = 9
+		case 33:
+			west, east = 9, 21
+		case 35:
+			west, east = 21, 33
+		case 37:
+			west = 33
+		case 32, 34, 36:
+			return 0, 0, 0, 0, fmt.Errorf("grid zone does not exist (Svalbard exception), gzd = %d%c", zoneNumber, zoneLetter)
+		}
+	}
+
+	return south, north, west, east, nil
+}
+
+/*
+checkInZone checks that the MGRS cell (south-west corner in UTM, edge length in meters) overlaps
+its grid zone designation with a positive area. The cell is sampled on a 5x5 grid, additionally the
+corners of the grid zone are tested against the cell (cells larger than the zone width near 84°N).
+utm holds the south-west corner of the cell.
+size holds the edge length of the cell in meters (100000 for a 100k square without digits).
+*/
+func checkInZone(utm UTM, size float64) error {
+
+	south, north, west, east, err := gzdBounds(utm.ZoneNumber, utm.ZoneLetter)
+	if err != nil {
+		return fmt.Errorf("%w, %v", ErrSquareNotInZone, err)
+	}
+
+	inZone := func(ll LL) bool {
+		return ll.Lat >= south-gzdTolerance && ll.Lat <= north+gzdTolerance &&
+			ll.Lon >= west-gzdTolerance && ll.Lon <= east+gzdTolerance
+	}
+
+	// samples on the boundary (tolerance) only count if the cell also reaches into the zone, cells
+	// with an edge on the boundary (central meridian, equator) but outside of the zone have no area
+	found := false
+	reachesSouth, reachesNorth, reachesWest, reachesEast := false, false, false, false
+	const steps = 4
+	for i := 0; i <= steps; i++ {
+		for j := 0; j <= steps; j++ {
+			sample := utm
+			sample.Easting += size * float64(i) / steps
+			sample.Northing += size * float64(j) / steps
+			ll, err := sample.ToLL()
+			if err != nil {
+				continue
+			}
+			found = found || inZone(ll)
+			reachesSouth = reachesSouth || ll.Lat < north
+			reachesNorth = reachesNorth || ll.Lat > south
+			reachesWest = reachesWest || ll.Lon < east
+			reachesEast = reachesEast || ll.Lon > west
+			if found && reachesSouth && reachesNorth && reachesWest && reachesEast {
+				return nil
+			}
+		}
+	}
+
+	tm := utmProjection(EllipsoidWGS84, Krueger, utm.ZoneNumber)
+	if utm.Hemisphere() == SouthernHemisphere {
+		tm.falseNorthing = 10000000.0
+	}
+	for _, lat := range []float64{south, north} {
+		for _, lon := range []float64{west, east} {
+			easting, northing := tm.forward(lat, lon)
+			if easting > utm.Easting && easting < utm.Easting+size &&
+				northing > utm.Northing && northing < utm.Northing+size {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w, gzd = %d%c (lat %v..%v, lon %v..%v), utm = %s",
+		ErrSquareNotInZone, utm.ZoneNumber, utm.ZoneLetter, south, north, west, east, utm)
+}
+
+/*
+checkInPolarCap checks that the polar MGRS cell (south-west corner in UPS, edge length in meters)
+overlaps its polar cap (north of 84°N, south of 80°S). The point of the cell nearest to the pole
+is tested, the latitude decreases monotonically with the distance from the pole.
+ups holds the south-west corner of the cell.
+size holds the edge length of the cell in meters.
+*/
+func checkInPolarCap(ups UPS, size float64) error {
+
+	nearest := ups
+	nearest.Easting = math.Max(ups.Easting, math.Min(upsFalseEasting, ups.Easting+size))
+	nearest.Northing = math.Max(ups.Northing, math.Min(upsFalseNorthing, ups.Northing+size))
+
+	ll, err := nearest.ToLL()
+	if err != nil {
+		return fmt.Errorf("%w, %v", ErrSquareNotInZone, err)
+	}
+
+	if ups.isNorth() {
+		if ll.Lat < 84-gzdTolerance {
+			return fmt.Errorf("%w, polar cap = %c (lat 84..90), ups = %s", ErrSquareNotInZone, ups.ZoneLetter, ups)
+		}
+	} else if ll.Lat > -80+gzdTolerance {
+		return fmt.Errorf("%w, polar cap = %c (lat -90..-80), ups = %s", ErrSquareNotInZone, ups.ZoneLetter, ups)
+	}
+
+	return nil
+}
+
+/*
+Validate checks the MGRS/UTMREF string: syntax (see ParseMGRS) and existence of the
+100k square in the stated grid zone (errors.Is(err, ErrSquareNotInZone)).
+*/
+func (mgrs MGRS) Validate() error {
+
+	if mgrs.isPolar() {
+		_, _, err := mgrs.ToUPS()
+		return err
+	}
+
+	_, _, err := mgrs.ToUTM()
+	return err
+}
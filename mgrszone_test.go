@@ -0,0 +1,95 @@
+/*
+Purpose:
+- MGRS/UTMREF grid zone validation
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMGRS_Validate(t *testing.T) {
+
+	var tests = []struct {
+		mgrs   MGRS // in
+		inZone bool // out
+	}{
+		// positive tests
+		{"32ULC", true},
+		{"32UKU", true},
+		{"32ULC9897356497", true},
+		{"33UXP04", true},
+		{"31UFU", true}, // 600 km easting exists in the regular zone 31U
+		{"31VDG", true}, // 31V ends at 3°E (central meridian)
+		{"32VJM", true}, // 32V is extended westwards to 3°E
+		{"31XGA", true}, // 31X is extended eastwards to 9°E
+		{"33XVG", true}, // 33X is extended westwards to 9°E
+		{"ZAF152844", true},
+		// negative tests
+		{"32UJU", false}, // easting west of 6°E
+		{"32UMQ", false}, // northing north of band U (letter pair repeats every 2000 km)
+		{"31VFG", false}, // easting east of 3°E (Norway exception)
+		{"31VEG", false}, // west edge on 3°E, no area in 31V
+		{"31VEJ", false}, // west edge on 3°E, no area in 31V
+		{"31MBA", false}, // south edge on the equator, no area in band M
+		{"31NBV", false}, // north edge on the equator, no area in band N
+		{"31XGH", false}, // northing north of 84°N
+		{"32XMA", false}, // grid zone does not exist (Svalbard exception)
+		{"01CCM", false},
+		{"AKZ", false}, // north of 80°S (polar grid is square)
+		{"YRA", false}, // south of 84°N
+		{"ZJB", false},
+	}
+
+	for _, test := range tests {
+		err := test.mgrs.Validate()
+		function := fmt.Sprintf("MGRS(%s).Validate()", test.mgrs)
+		got := fmt.Sprintf("%v", err == nil)
+		want := fmt.Sprintf("%v", test.inZone)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s (%v)\n", function, got, want, err)
+		}
+		if !test.inZone && !errors.Is(err, ErrSquareNotInZone) {
+			t.Errorf("\n%s -> %v is not ErrSquareNotInZone\n", function, err)
+		}
+	}
+
+	_, _, err := MGRS("32UMQ").ToLL()
+	if !errors.Is(err, ErrSquareNotInZone) {
+		t.Errorf("\nMGRS(32UMQ).ToLL() -> %v is not ErrSquareNotInZone\n", err)
+	}
+}
+
+func TestMGRS_ValidateRoundTrip(t *testing.T) {
+
+	// every MGRS generated from Lon Lat must exist in its grid zone (boundaries and exceptions included)
+	for lat := -80.0; lat <= 84.0; lat++ {
+		for lon := -180.0; lon < 180.0; lon++ {
+			for _, accuracy := range []int{1, 10000} {
+				ll := LL{Lat: lat, Lon: lon}
+				mgrs, err := ll.ToMGRS(accuracy)
+				if err != nil {
+					t.Fatalf("\n%#v.ToMGRS(%d) -> %v\n", ll, accuracy, err)
+				}
+				if err := mgrs.Validate(); err != nil {
+					t.Errorf("\n%#v.ToMGRS(%d) = %s -> %v\n", ll, accuracy, mgrs, err)
+				}
+			}
+		}
+	}
+}
+
+func ExampleMGRS_Validate() {
+
+	err := MGRS("32UMQ").Validate()
+	fmt.Println(errors.Is(err, ErrSquareNotInZone))
+
+	// Output:
+	// true
+}
@@ -0,0 +1,275 @@
+/*
+Purpose:
+- British National Grid (OSGB36) <-> Lon Lat, grid references ("TQ 30080 80500")
+
+Description:
+- Ordnance Survey National Grid: transverse Mercator on the Airy 1830 ellipsoid (OSGB36 datum)
+  with 100 km square letters (e.g. "TQ") and 2- to 10-digit grid references.
+
+Remarks:
+- Projection parameters: latitude of origin 49°N, central meridian 2°W, scale 0.9996012717,
+  false easting 400000 m, false northing -100000 m.
+- Conversion from/to WGS84 uses the Helmert transformation of the OSGB36 datum (accuracy about 5 m).
+  For survey accuracy the OSTN15 grid transformation is required (not supported).
+
+Links:
+- https://www.ordnancesurvey.co.uk/documents/resources/guide-coordinate-systems-great-britain.pdf
+- https://www.movable-type.co.uk/scripts/latlong-os-gridref.html
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// OSGB defines coordinate in British National Grid (OSGB36)
+type OSGB struct {
+	Easting  float64
+	Northing float64
+}
+
+// British National Grid extent in meters
+const (
+	osgbMaxEasting  = 700000.0
+	osgbMaxNorthing = 1300000.0
+)
+
+/*
+osgbProjection returns the transverse Mercator parameters of the British National Grid.
+*/
+func osgbProjection() transverseMercator {
+
+	return transverseMercator{
+		ellipsoid:     EllipsoidAiry1830,
+		engine:        Krueger,
+		lat0:          49,
+		lon0:          -2,
+		k0:            0.9996012717,
+		falseEasting:  400000,
+		falseNorthing: -100000,
+	}
+}
+
+/*
+String returns stringified OSGB object (full easting and northing, rounded to meters).
+*/
+func (osgb OSGB) String() string {
+
+	return fmt.Sprintf("%.0f %.0f", roundValue(osgb.Easting, 1, RoundHalfUp), roundValue(osgb.Northing, 1, RoundHalfUp))
+}
+
+/*
+ToOSGB converts Lon Lat (WGS84 datum) to British National Grid.
+*/
+func (ll LL) ToOSGB() (OSGB, error) {
+
+	return ll.ToOSGBDatum(WGS84)
+}
+
+/*
+ToOSGBDatum converts Lon Lat to British National Grid.
+datum holds the datum of the Lon Lat coordinate (e.g. WGS84 or OSGB36).
+*/
+func (ll LL) ToOSGBDatum(datum Datum) (OSGB, error) {
+
+	if ll.Lon < -180 || ll.Lon > 180 {
+		return OSGB{}, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if ll.Lat < -90 || ll.Lat > 90 {
+		return OSGB{}, fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	llOSGB36 := ll.Transform(datum, OSGB36)
+
+	osgb := OSGB{}
+	osgb.Easting, osgb.Northing = osgbProjection().forward(llOSGB36.Lat, llOSGB36.Lon)
+	if !osgb.inGrid() {
+		return OSGB{}, fmt.Errorf("position outside of british national grid, ll = %s", ll)
+	}
+
+	return osgb, nil
+}
+
+/*
+ToLL converts British National Grid to Lon Lat (WGS84 datum).
+*/
+func (osgb OSGB) ToLL() (LL, error) {
+
+	return osgb.ToLLDatum(WGS84)
+}
+
+/*
+ToLLDatum converts British National Grid to Lon Lat.
+datum holds the wanted datum of the Lon Lat coordinate (e.g. WGS84 or OSGB36).
+*/
+func (osgb OSGB) ToLLDatum(datum Datum) (LL, error) {
+
+	if !osgb.inGrid() {
+		return LL{}, fmt.Errorf("position outside of british national grid, osgb = %s", osgb)
+	}
+
+	ll := LL{}
+	ll.Lat, ll.Lon = osgbProjection().inverse(osgb.Easting, osgb.Northing)
+
+	return ll.Transform(OSGB36, datum), nil
+}
+
+/*
+inGrid reports whether the coordinate lies inside the British National Grid (0-700 km east, 0-1300 km north).
+*/
+func (osgb OSGB) inGrid() bool {
+
+	return osgb.Easting >= 0 && osgb.Easting < osgbMaxEasting && osgb.Northing >= 0 && osgb.Northing < osgbMaxNorthing
+}
+
+/*
+GridRef returns the grid reference (e.g. "TQ 300 805" for 6 digits).
+Easting and northing are truncated to the accuracy (grid reference convention).
+digits holds the total number of digits (2, 4, 6, 8 or 10).
+*/
+func (osgb OSGB) GridRef(digits int) (string, error) {
+
+	if digits < 2 || digits > 10 || digits%2 != 0 {
+		return "", fmt.Errorf("invalid number of digits (2, 4, 6, 8 or 10), digits = %d", digits)
+	}
+	if !osgb.inGrid() {
+		return "", fmt.Errorf("position outside of british national grid, osgb = %s", osgb)
+	}
+
+	easting := roundValue(osgb.Easting, 1, Truncate)
+	northing := roundValue(osgb.Northing, 1, Truncate)
+	e100k := int(math.Floor(easting / 100000))
+	n100k := int(math.Floor(northing / 100000))
+
+	// first letter: 500 km square (origin at 'S'), second letter: 100 km square
+	l1 := (19 - n100k) - (19-n100k)%5 + (e100k+10)/5
+	l2 := (19-n100k)*5%25 + e100k%5
+
+	half := digits / 2
+	return fmt.Sprintf("%c%c %s %s", gridLetter(l1), gridLetter(l2),
+		mgrsDigits(math.Mod(easting, 100000), half), mgrsDigits(math.Mod(northing, 100000), half)), nil
+}
+
+/*
+gridLetter returns the letter for the index in the 5x5 letter grid (A-Z without I).
+*/
+func gridLetter(index int) byte {
+
+	if index > 7 {
+		index++
+	}
+
+	return byte('A' + index)
+}
+
+/*
+gridIndex returns the index in the 5x5 letter grid (A-Z without I) for the letter, -1 for an invalid letter.
+*/
+func gridIndex(letter rune) int {
+
+	letter = unicode.ToUpper(letter)
+	if letter < 'A' || letter > 'Z' || letter == 'I' {
+		return -1
+	}
+
+	index := int(letter - 'A')
+	if index > 7 {
+		index--
+	}
+
+	return index
+}
+
+/*
+ParseOSGBGridRef parses a British National Grid reference (e.g. "TQ 30080 80500", "tq3008080500", "TQ 3 8").
+The returned coordinate is the south-west corner of the referenced square, the accuracy is returned in meters.
+s holds the grid reference.
+*/
+func ParseOSGBGridRef(s string) (OSGB, int, error) {
+
+	letters, fields, err := splitGridRef(s, 2)
+	if err != nil {
+		return OSGB{}, 0, err
+	}
+
+	l1 := gridIndex(letters[0])
+	l2 := gridIndex(letters[1])
+	e100k := ((l1-2)%5+5)%5*5 + l2%5
+	n100k := (19 - l1/5*5) - l2/5
+	if e100k > 6 || n100k < 0 || n100k > 12 {
+		return OSGB{}, 0, fmt.Errorf("grid square outside of british national grid, gridref = %s", s)
+	}
+
+	easting, northing, accuracy, err := gridRefDigits(s, fields)
+	if err != nil {
+		return OSGB{}, 0, err
+	}
+
+	osgb := OSGB{}
+	osgb.Easting = float64(e100k*100000) + easting
+	osgb.Northing = float64(n100k*100000) + northing
+
+	return osgb, accuracy, nil
+}
+
+/*
+splitGridRef splits a letter-prefixed grid reference into its letters (A-Z without I) and digit groups.
+s holds the grid reference.
+count holds the number of leading letters.
+*/
+func splitGridRef(s string, count int) ([]rune, []string, error) {
+
+	input := []rune(strings.TrimSpace(s))
+	letters := []rune{}
+	i := 0
+	for len(letters) < count {
+		for i < len(input) && unicode.IsSpace(input[i]) {
+			i++
+		}
+		if i == len(input) {
+			return nil, nil, fmt.Errorf("missing grid letter, gridref = %s", s)
+		}
+		if gridIndex(input[i]) < 0 {
+			return nil, nil, fmt.Errorf("invalid grid letter %q, gridref = %s", input[i], s)
+		}
+		letters = append(letters, unicode.ToUpper(input[i]))
+		i++
+	}
+
+	return letters, strings.Fields(string(input[i:])), nil
+}
+
+/*
+gridRefDigits returns easting and northing (in meters within the 100 km square) and the accuracy of the digits.
+The digits are given as one group (split in the middle) or as two groups of equal length.
+s holds the grid reference (for error messages).
+fields holds the digit groups.
+*/
+func gridRefDigits(s string, fields []string) (float64, float64, int, error) {
+
+	if len(fields) > 2 || (len(fields) == 2 && len(fields[0]) != len(fields[1])) {
+		return 0, 0, 0, fmt.Errorf("invalid digit groups, gridref = %s", s)
+	}
+
+	digits := strings.Join(fields, "")
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, 0, 0, fmt.Errorf("unexpected character %q, gridref = %s", r, s)
+		}
+	}
+	if len(digits) > 10 || len(digits)%2 != 0 {
+		return 0, 0, 0, fmt.Errorf("invalid number of digits (0-10, even), gridref = %s", s)
+	}
+
+	c := MGRSComponents{Easting: digits[:len(digits)/2], Northing: digits[len(digits)/2:], Precision: len(digits) / 2}
+	easting, northing, accuracy := c.values()
+	if accuracy == 0 {
+		accuracy = 100000
+	}
+
+	return easting, northing, accuracy, nil
+}
@@ -0,0 +1,182 @@
+/*
+Purpose:
+- British National Grid (OSGB36) <-> Lon Lat, grid references ("TQ 30080 80500")
+
+Description:
+- testing
+
+Remarks:
+- Projection example (Caister water tower) from "A guide to coordinate systems in Great Britain" (Ordnance Survey).
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"testing"
+)
+
+func TestLL_ToOSGBDatum(t *testing.T) {
+
+	var tests = []struct {
+		ll    LL    // in
+		datum Datum // in
+		osgb  OSGB  // out
+		err   error // out
+	}{
+		// positive tests
+		{LL{Lat: 52.657570305556, Lon: 1.717921583333}, OSGB36, OSGB{Easting: 651409.903, Northing: 313177.270}, nil},
+		{LL{Lat: 51.5007, Lon: -0.1246}, WGS84, OSGB{Easting: 530269.902, Northing: 179640.717}, nil},
+		{LL{Lat: 55.9533, Lon: -3.1883}, WGS84, OSGB{Easting: 325897.218, Northing: 674001.202}, nil},
+		{LL{Lat: 50.0657, Lon: -5.7132}, WGS84, OSGB{Easting: 134369.608, Northing: 25005.051}, nil},
+		// negative tests
+		{LL{Lat: 48.0, Lon: -10.0}, WGS84, OSGB{}, fmt.Errorf("position outside of british national grid, ll = 48.000000 -10.000000")},
+		{LL{Lat: 91.0, Lon: 0.0}, WGS84, OSGB{}, fmt.Errorf("invalid latitude, lat = 91")},
+	}
+
+	for _, test := range tests {
+		osgb, err := test.ll.ToOSGBDatum(test.datum)
+		function := fmt.Sprintf("%#v.ToOSGBDatum(%s)", test.ll, test.datum)
+		got := fmt.Sprintf("%.3f %.3f %v", osgb.Easting, osgb.Northing, err)
+		want := fmt.Sprintf("%.3f %.3f %v", test.osgb.Easting, test.osgb.Northing, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestOSGB_ToLLDatum(t *testing.T) {
+
+	var tests = []struct {
+		osgb  OSGB  // in
+		datum Datum // in
+		ll    LL    // out
+		err   error // out
+	}{
+		// positive tests
+		{OSGB{Easting: 651409.903, Northing: 313177.270}, OSGB36, LL{Lat: 52.657570305556, Lon: 1.717921583333}, nil},
+		{OSGB{Easting: 530269.902, Northing: 179640.717}, WGS84, LL{Lat: 51.5007, Lon: -0.1246}, nil},
+		// negative tests
+		{OSGB{Easting: 700000, Northing: 0}, WGS84, LL{}, fmt.Errorf("position outside of british national grid, osgb = 700000 0")},
+	}
+
+	for _, test := range tests {
+		ll, err := test.osgb.ToLLDatum(test.datum)
+		function := fmt.Sprintf("%#v.ToLLDatum(%s)", test.osgb, test.datum)
+		got := fmt.Sprintf("%.7f %.7f %v", ll.Lat, ll.Lon, err)
+		want := fmt.Sprintf("%.7f %.7f %v", test.ll.Lat, test.ll.Lon, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestOSGB_GridRef(t *testing.T) {
+
+	var tests = []struct {
+		osgb    OSGB   // in
+		digits  int    // in
+		gridRef string // out
+		err     error  // out
+	}{
+		// positive tests
+		{OSGB{Easting: 651409.903, Northing: 313177.270}, 10, "TG 51409 13177", nil},
+		{OSGB{Easting: 651409.903, Northing: 313177.270}, 8, "TG 5140 1317", nil},
+		{OSGB{Easting: 651409.903, Northing: 313177.270}, 6, "TG 514 131", nil},
+		{OSGB{Easting: 651409.903, Northing: 313177.270}, 4, "TG 51 13", nil},
+		{OSGB{Easting: 651409.903, Northing: 313177.270}, 2, "TG 5 1", nil},
+		{OSGB{Easting: 530080, Northing: 180500}, 10, "TQ 30080 80500", nil},
+		{OSGB{Easting: 0, Northing: 0}, 10, "SV 00000 00000", nil},
+		{OSGB{Easting: 440000, Northing: 1210000}, 4, "HP 40 10", nil},
+		// negative tests
+		{OSGB{Easting: 530080, Northing: 180500}, 5, "", fmt.Errorf("invalid number of digits (2, 4, 6, 8 or 10), digits = 5")},
+		{OSGB{Easting: -1, Northing: 180500}, 10, "", fmt.Errorf("position outside of british national grid, osgb = -1 180500")},
+	}
+
+	for _, test := range tests {
+		gridRef, err := test.osgb.GridRef(test.digits)
+		function := fmt.Sprintf("%#v.GridRef(%d)", test.osgb, test.digits)
+		got := fmt.Sprintf("%s %v", gridRef, err)
+		want := fmt.Sprintf("%s %v", test.gridRef, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestParseOSGBGridRef(t *testing.T) {
+
+	var tests = []struct {
+		s        string // in
+		osgb     OSGB   // out
+		accuracy int    // out
+		err      error  // out
+	}{
+		// positive tests
+		{"TQ 30080 80500", OSGB{Easting: 530080, Northing: 180500}, 1, nil},
+		{"tq3008080500", OSGB{Easting: 530080, Northing: 180500}, 1, nil},
+		{" TG 514 131 ", OSGB{Easting: 651400, Northing: 313100}, 100, nil},
+		{"TQ 3 8", OSGB{Easting: 530000, Northing: 180000}, 10000, nil},
+		{"TQ", OSGB{Easting: 500000, Northing: 100000}, 100000, nil},
+		{"HP 40 10", OSGB{Easting: 440000, Northing: 1210000}, 1000, nil},
+		// negative tests
+		{"XX", OSGB{}, 0, fmt.Errorf("grid square outside of british national grid, gridref = XX")},
+		{"TI123", OSGB{}, 0, fmt.Errorf("invalid grid letter 'I', gridref = TI123")},
+		{"T", OSGB{}, 0, fmt.Errorf("missing grid letter, gridref = T")},
+		{"TA 1234 567", OSGB{}, 0, fmt.Errorf("invalid digit groups, gridref = TA 1234 567")},
+		{"TQ 12a4", OSGB{}, 0, fmt.Errorf("unexpected character 'a', gridref = TQ 12a4")},
+		{"TQ 123", OSGB{}, 0, fmt.Errorf("invalid number of digits (0-10, even), gridref = TQ 123")},
+	}
+
+	for _, test := range tests {
+		osgb, accuracy, err := ParseOSGBGridRef(test.s)
+		function := fmt.Sprintf("ParseOSGBGridRef(%s)", test.s)
+		got := fmt.Sprintf("%s %d %v", osgb, accuracy, err)
+		want := fmt.Sprintf("%s %d %v", test.osgb, test.accuracy, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleLL_ToOSGB() {
+
+	ll := LL{Lat: 51.5007, Lon: -0.1246}
+	osgb, err := ll.ToOSGB()
+	if err != nil {
+		log.Printf("error <%v> at ll.ToOSGB()", err)
+		return
+	}
+	gridRef, err := osgb.GridRef(10)
+	if err != nil {
+		log.Printf("error <%v> at osgb.GridRef()", err)
+		return
+	}
+	fmt.Println(osgb)
+	fmt.Println(gridRef)
+
+	// Output:
+	// 530270 179641
+	// TQ 30269 79640
+}
+
+func ExampleParseOSGBGridRef() {
+
+	osgb, accuracy, err := ParseOSGBGridRef("TQ 30080 80500")
+	if err != nil {
+		log.Printf("error <%v> at ParseOSGBGridRef()", err)
+		return
+	}
+	ll, err := osgb.ToLL()
+	if err != nil {
+		log.Printf("error <%v> at osgb.ToLL()", err)
+		return
+	}
+	fmt.Println(osgb, accuracy)
+	fmt.Println(ll)
+
+	// Output:
+	// 530080 180500 1
+	// 51.508466 -0.127018
+}
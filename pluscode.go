@@ -0,0 +1,462 @@
+/*
+Purpose:
+- Open Location Code (Plus Codes) <-> Lon Lat, UTM, MGRS
+
+Description:
+- Full codes (e.g. "9F4MGC7R+2F") encode a position as base20 digit pairs (code length 2 to 10),
+  refined by a 4 x 5 grid per additional digit (code length 11 to 15).
+- Short codes (e.g. "GC7R+2F") omit leading digits and are recovered relative to a reference position.
+- Decoding returns the code area (bounding box) and its center.
+
+Remarks:
+- Encoding works on integer values (1/8000° pairs, 1/25000000° x 1/8192000° grid) like the reference
+  implementation, this avoids floating point errors at the cell edges.
+- Padded codes (e.g. "9F4M0000+") are valid full codes with code length < 8.
+- Short codes must be recovered (Recover) before they can be decoded.
+
+Links:
+- https://github.com/google/open-location-code/blob/main/docs/specification.md
+- https://maps.google.com/pluscodes/
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// PlusCode defines a code area in Open Location Code notation
+type PlusCode string
+
+// Open Location Code constants
+const (
+	olcAlphabet       = "23456789CFGHJMPQRVWX"
+	olcSeparator      = '+'
+	olcSeparatorPos   = 8
+	olcPadding        = '0'
+	olcEncBase        = 20
+	olcPairCodeLen    = 10
+	olcMaxCodeLen     = 15
+	olcGridCols       = 4
+	olcGridRows       = 5
+	olcPairPrecision  = 8000     // 20^3, pair resolution of the last pair in 1/8000 degree
+	olcFinalLatPrec   = 25000000 // 8000 * 5^5
+	olcFinalLonPrec   = 8192000  // 8000 * 4^5
+	olcPairFirstPlace = 160000   // 20^4
+	olcGridLatFirst   = 625      // 5^4
+	olcGridLonFirst   = 256      // 4^4
+	olcLatMax         = 90
+	olcLonMax         = 180
+	olcFullCodeLen    = olcPairCodeLen // default code length (area 14 m x 14 m)
+)
+
+// olcPairResolutions holds the pair resolutions in degrees
+var olcPairResolutions = []float64{20.0, 1.0, 0.05, 0.0025, 0.000125}
+
+/*
+ToPlusCode converts Lon Lat to a full Plus Code.
+length holds the wanted code length (2, 4, 6, 8, 10 to 15 digits, 10 is the common precision of about 14 m).
+*/
+func (ll LL) ToPlusCode(length int) (PlusCode, error) {
+
+	if length < 2 || (length < olcPairCodeLen && length%2 == 1) || length > olcMaxCodeLen {
+		return "", fmt.Errorf("invalid code length (2, 4, 6, 8, 10-15), length = %d", length)
+	}
+	if math.IsNaN(ll.Lat) || math.IsNaN(ll.Lon) || math.IsInf(ll.Lat, 0) || math.IsInf(ll.Lon, 0) {
+		return "", fmt.Errorf("invalid position, ll = %s", ll)
+	}
+
+	// latitude is clipped, longitude is normalized (before scaling, large values overflow int64)
+	lat := math.Max(-olcLatMax, math.Min(olcLatMax, ll.Lat))
+	latVal := int64(math.Round((lat+olcLatMax)*olcFinalLatPrec*1e6) / 1e6)
+	lonVal := int64(math.Round((normalizeLon(ll.Lon)+olcLonMax)*olcFinalLonPrec*1e6) / 1e6)
+
+	// the north pole is encoded into the cell below
+	if latVal >= 2*olcLatMax*olcFinalLatPrec {
+		latVal = 2*olcLatMax*olcFinalLatPrec - 1
+	}
+	lonRange := int64(2 * olcLonMax * olcFinalLonPrec)
+	lonVal = ((lonVal % lonRange) + lonRange) % lonRange
+
+	code := make([]byte, olcMaxCodeLen)
+
+	// grid digits (4 columns x 5 rows)
+	if length > olcPairCodeLen {
+		for i := olcMaxCodeLen - 1; i >= olcPairCodeLen; i-- {
+			latDigit := latVal % olcGridRows
+			lonDigit := lonVal % olcGridCols
+			code[i] = olcAlphabet[latDigit*olcGridCols+lonDigit]
+			latVal /= olcGridRows
+			lonVal /= olcGridCols
+		}
+	} else {
+		latVal /= olcGridLatFirst * olcGridRows
+		lonVal /= olcGridLonFirst * olcGridCols
+	}
+
+	// pair digits (latitude, longitude)
+	for i := olcPairCodeLen/2 - 1; i >= 0; i-- {
+		code[2*i+1] = olcAlphabet[lonVal%olcEncBase]
+		code[2*i] = olcAlphabet[latVal%olcEncBase]
+		latVal /= olcEncBase
+		lonVal /= olcEncBase
+	}
+
+	digits := string(code)
+	if length >= olcSeparatorPos {
+		return PlusCode(digits[:olcSeparatorPos] + string(olcSeparator) + digits[olcSeparatorPos:length]), nil
+	}
+
+	return PlusCode(digits[:length] + strings.Repeat(string(olcPadding), olcSeparatorPos-length) + string(olcSeparator)), nil
+}
+
+/*
+Validate checks the Plus Code syntax (full or short code, case insensitive).
+*/
+func (pc PlusCode) Validate() error {
+
+	code := strings.ToUpper(string(pc))
+	if len(code) < 2 {
+		return fmt.Errorf("plus code too short, pluscode = %s", pc)
+	}
+
+	separator := strings.IndexRune(code, olcSeparator)
+	if separator < 0 || strings.Count(code, string(olcSeparator)) != 1 {
+		return fmt.Errorf("plus code requires exactly one separator '+', pluscode = %s", pc)
+	}
+	if separator > olcSeparatorPos || separator%2 == 1 {
+		return fmt.Errorf("invalid separator position, pluscode = %s", pc)
+	}
+
+	// padding is only allowed in full codes, in pairs and directly before the separator
+	padding := strings.IndexRune(code, olcPadding)
+	if padding >= 0 {
+		if separator < olcSeparatorPos {
+			return fmt.Errorf("padding not allowed in short codes, pluscode = %s", pc)
+		}
+		if padding == 0 || padding%2 == 1 {
+			return fmt.Errorf("invalid padding position, pluscode = %s", pc)
+		}
+		if strings.Trim(code[padding:separator], string(olcPadding)) != "" || len(code) > separator+1 {
+			return fmt.Errorf("invalid padding, pluscode = %s", pc)
+		}
+	}
+
+	if len(code)-separator-1 == 1 {
+		return fmt.Errorf("single digit after separator not allowed, pluscode = %s", pc)
+	}
+	if len(code)-1 > olcMaxCodeLen {
+		return fmt.Errorf("too many digits (max %d), pluscode = %s", olcMaxCodeLen, pc)
+	}
+
+	for _, char := range code {
+		if char == olcSeparator || char == olcPadding {
+			continue
+		}
+		if !strings.ContainsRune(olcAlphabet, char) {
+			return fmt.Errorf("invalid plus code character %q, pluscode = %s", char, pc)
+		}
+	}
+
+	return nil
+}
+
+/*
+IsFull reports whether the Plus Code is a valid full code.
+*/
+func (pc PlusCode) IsFull() bool {
+
+	if pc.Validate() != nil {
+		return false
+	}
+	code := strings.ToUpper(string(pc))
+	if strings.IndexRune(code, olcSeparator) != olcSeparatorPos {
+		return false
+	}
+
+	// first latitude digit must be below 90°N, first longitude digit below 180°E
+	if strings.IndexByte(olcAlphabet, code[0])*olcEncBase >= 2*olcLatMax {
+		return false
+	}
+	if len(code) > 1 && strings.IndexByte(olcAlphabet, code[1])*olcEncBase >= 2*olcLonMax {
+		return false
+	}
+
+	return true
+}
+
+/*
+IsShort reports whether the Plus Code is a valid short code (leading digits omitted).
+*/
+func (pc PlusCode) IsShort() bool {
+
+	if pc.Validate() != nil {
+		return false
+	}
+
+	return strings.IndexRune(string(pc), olcSeparator) < olcSeparatorPos
+}
+
+/*
+digits returns the upper case digits of the Plus Code (without separator and padding).
+*/
+func (pc PlusCode) digits() string {
+
+	code := strings.ToUpper(string(pc))
+	code = strings.Replace(code, string(olcSeparator), "", 1)
+
+	return strings.TrimRight(code, string(olcPadding))
+}
+
+/*
+Length returns the code length (number of digits without separator and padding).
+*/
+func (pc PlusCode) Length() int {
+
+	return len(pc.digits())
+}
+
+/*
+Bounds returns the code area (bounding box) of a full Plus Code.
+*/
+func (pc PlusCode) Bounds() (BoundingBox, error) {
+
+	if !pc.IsFull() {
+		if err := pc.Validate(); err != nil {
+			return BoundingBox{}, err
+		}
+		if pc.IsShort() {
+			return BoundingBox{}, fmt.Errorf("short plus code (recover with reference position first), pluscode = %s", pc)
+		}
+		return BoundingBox{}, fmt.Errorf("plus code outside of valid range, pluscode = %s", pc)
+	}
+
+	code := pc.digits()
+
+	// pair digits
+	normalLat := int64(-olcLatMax * olcPairPrecision)
+	normalLon := int64(-olcLonMax * olcPairPrecision)
+	placeValue := int64(olcPairFirstPlace)
+	digits := len(code)
+	if digits > olcPairCodeLen {
+		digits = olcPairCodeLen
+	}
+	for i := 0; i < digits; i += 2 {
+		normalLat += int64(strings.IndexByte(olcAlphabet, code[i])) * placeValue
+		normalLon += int64(strings.IndexByte(olcAlphabet, code[i+1])) * placeValue
+		if i < digits-2 {
+			placeValue /= olcEncBase
+		}
+	}
+	latPrecision := float64(placeValue) / olcPairPrecision
+	lonPrecision := float64(placeValue) / olcPairPrecision
+
+	// grid digits
+	extraLat := int64(0)
+	extraLon := int64(0)
+	if len(code) > olcPairCodeLen {
+		rowPlace := int64(olcGridLatFirst)
+		colPlace := int64(olcGridLonFirst)
+		for i := olcPairCodeLen; i < len(code); i++ {
+			digit := int64(strings.IndexByte(olcAlphabet, code[i]))
+			extraLat += digit / olcGridCols * rowPlace
+			extraLon += digit % olcGridCols * colPlace
+			if i < len(code)-1 {
+				rowPlace /= olcGridRows
+				colPlace /= olcGridCols
+			}
+		}
+		latPrecision = float64(rowPlace) / olcFinalLatPrec
+		lonPrecision = float64(colPlace) / olcFinalLonPrec
+	}
+
+	bbox := BoundingBox{}
+	bbox.South = float64(normalLat)/olcPairPrecision + float64(extraLat)/olcFinalLatPrec
+	bbox.West = float64(normalLon)/olcPairPrecision + float64(extraLon)/olcFinalLonPrec
+	bbox.North = bbox.South + latPrecision
+	bbox.East = bbox.West + lonPrecision
+
+	return bbox, nil
+}
+
+/*
+ToLL converts a full Plus Code to Lon Lat (center of the code area).
+*/
+func (pc PlusCode) ToLL() (LL, error) {
+
+	bbox, err := pc.Bounds()
+	if err != nil {
+		return LL{}, err
+	}
+
+	return bbox.Center(), nil
+}
+
+/*
+Shorten removes as many leading digits as possible, so that the code can be recovered
+with the reference position (the reference must be within about 0.3 code areas of the removed precision).
+ref holds the reference position (e.g. center of the town or the map view).
+*/
+func (pc PlusCode) Shorten(ref LL) (PlusCode, error) {
+
+	if !pc.IsFull() {
+		return "", fmt.Errorf("plus code is not a valid full code, pluscode = %s", pc)
+	}
+	if strings.ContainsRune(string(pc), olcPadding) {
+		return "", fmt.Errorf("padded plus code cannot be shortened, pluscode = %s", pc)
+	}
+
+	code := PlusCode(strings.ToUpper(string(pc)))
+	center, err := code.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at pc.ToLL(), pluscode = %s", err, pc)
+	}
+
+	refLat := math.Max(-olcLatMax, math.Min(olcLatMax, ref.Lat))
+	refLon := normalizeLon(ref.Lon)
+	distance := math.Max(math.Abs(center.Lat-refLat), math.Abs(normalizeLon(center.Lon-refLon)))
+
+	for i := len(olcPairResolutions) - 2; i >= 1; i-- {
+		// at least two digits (one pair) must remain
+		if len(code)-1-(i+1)*2 < 2 {
+			continue
+		}
+		// the reference must be well within the area of the remaining code
+		if distance < olcPairResolutions[i]*0.3 {
+			return code[(i+1)*2:], nil
+		}
+	}
+
+	return code, nil
+}
+
+/*
+Recover restores a full Plus Code from a short code with the nearest matching position to the reference.
+Full codes are returned unchanged (upper case).
+ref holds the reference position (e.g. center of the town or the map view).
+*/
+func (pc PlusCode) Recover(ref LL) (PlusCode, error) {
+
+	if !pc.IsShort() {
+		if pc.IsFull() {
+			return PlusCode(strings.ToUpper(string(pc))), nil
+		}
+		return "", fmt.Errorf("plus code is not a valid short code, pluscode = %s", pc)
+	}
+
+	refLat := math.Max(-olcLatMax, math.Min(olcLatMax, ref.Lat))
+	refLon := normalizeLon(ref.Lon)
+	code := strings.ToUpper(string(pc))
+
+	// the missing leading digits are taken from the reference position
+	paddingLen := olcSeparatorPos - strings.IndexRune(code, olcSeparator)
+	resolution := math.Pow(olcEncBase, float64(2-paddingLen/2))
+	halfResolution := resolution / 2
+
+	refCode, err := LL{Lat: refLat, Lon: refLon}.ToPlusCode(olcFullCodeLen)
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at ll.ToPlusCode(), ref = %s", err, ref)
+	}
+	candidate := PlusCode(string(refCode)[:paddingLen] + code)
+	center, err := candidate.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at pc.ToLL(), pluscode = %s", err, candidate)
+	}
+
+	// move the candidate by one resolution step if the reference is nearer to the neighbouring area
+	if refLat+halfResolution < center.Lat && center.Lat-resolution >= -olcLatMax {
+		center.Lat -= resolution
+	} else if refLat-halfResolution > center.Lat && center.Lat+resolution <= olcLatMax {
+		center.Lat += resolution
+	}
+	if refLon+halfResolution < center.Lon {
+		center.Lon -= resolution
+	} else if refLon-halfResolution > center.Lon {
+		center.Lon += resolution
+	}
+	center.Lon = normalizeLon(center.Lon)
+
+	return center.ToPlusCode(candidate.Length())
+}
+
+/*
+normalizeLon normalizes the longitude into the range -180 <= lon < 180.
+*/
+func normalizeLon(lon float64) float64 {
+
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+
+	return lon - 180
+}
+
+/*
+cellIndex returns the number of whole grid units in the offset (index of the cell containing the position).
+The product is rounded to 1e-6 units before flooring, otherwise binary representation errors would move
+positions on a cell edge into the cell below (e.g. 0.3 * 10 = 2.9999999999999996).
+offset holds the offset from the grid origin in degrees (e.g. lon + 180).
+units holds the number of grid units per degree.
+*/
+func cellIndex(offset, units float64) int {
+
+	return int(math.Floor(math.Round(offset*units*1e6) / 1e6))
+}
+
+/*
+ToMGRS converts a full Plus Code (center of the code area) to MGRS/UTMREF.
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+*/
+func (pc PlusCode) ToMGRS(accuracy int) (MGRS, error) {
+
+	ll, err := pc.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at pc.ToLL(), pluscode = %s", err, pc)
+	}
+
+	return ll.ToMGRS(accuracy)
+}
+
+/*
+ToUTM converts a full Plus Code (center of the code area) to UTM.
+*/
+func (pc PlusCode) ToUTM() (UTM, error) {
+
+	ll, err := pc.ToLL()
+	if err != nil {
+		return UTM{}, fmt.Errorf("error <%w> at pc.ToLL(), pluscode = %s", err, pc)
+	}
+
+	return ll.ToUTM(), nil
+}
+
+/*
+ToPlusCode converts MGRS/UTMREF (south-west corner of the MGRS cell) to a full Plus Code.
+length holds the wanted code length (2, 4, 6, 8, 10 to 15 digits).
+*/
+func (mgrs MGRS) ToPlusCode(length int) (PlusCode, error) {
+
+	ll, _, err := mgrs.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at mgrs.ToLL(), mgrs = %s", err, mgrs)
+	}
+
+	return ll.ToPlusCode(length)
+}
+
+/*
+ToPlusCode converts UTM to a full Plus Code.
+length holds the wanted code length (2, 4, 6, 8, 10 to 15 digits).
+*/
+func (utm UTM) ToPlusCode(length int) (PlusCode, error) {
+
+	ll, err := utm.ToLL()
+	if err != nil {
+		return "", fmt.Errorf("error <%w> at utm.ToLL(), utm = %s", err, utm)
+	}
+
+	return ll.ToPlusCode(length)
+}
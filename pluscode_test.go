@@ -0,0 +1,272 @@
+/*
+Purpose:
+- Open Location Code (Plus Codes) <-> Lon Lat, UTM, MGRS
+
+Description:
+- testing
+
+Remarks:
+- Test values from the Open Location Code reference test data.
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"testing"
+)
+
+func TestLL_ToPlusCode(t *testing.T) {
+
+	var tests = []struct {
+		ll       LL       // in
+		length   int      // in
+		pluscode PlusCode // out
+		err      error    // out
+	}{
+		// positive tests
+		{LL{Lat: 20.375, Lon: 2.775}, 6, "7FG49Q00+", nil},
+		{LL{Lat: 20.3700625, Lon: 2.7821875}, 10, "7FG49QCJ+2V", nil},
+		{LL{Lat: 20.3701125, Lon: 2.782234375}, 11, "7FG49QCJ+2VX", nil},
+		{LL{Lat: 20.3701135, Lon: 2.78223535156}, 13, "7FG49QCJ+2VXGJ", nil},
+		{LL{Lat: 47.0000625, Lon: 8.0000625}, 10, "8FVC2222+22", nil},
+		{LL{Lat: -41.2730625, Lon: 174.7859375}, 10, "4VCPPQGP+Q9", nil},
+		{LL{Lat: 0.5, Lon: -179.5}, 4, "62G20000+", nil},
+		{LL{Lat: -89.5, Lon: -179.5}, 4, "22220000+", nil},
+		{LL{Lat: -89.9999375, Lon: -179.9999375}, 10, "22222222+22", nil},
+		{LL{Lat: 0.5, Lon: 179.5}, 4, "6VGX0000+", nil},
+		{LL{Lat: 1, Lon: 1}, 11, "6FH32222+222", nil},
+		{LL{Lat: 90, Lon: 1}, 4, "CFX30000+", nil}, // north pole is encoded into the cell below
+		{LL{Lat: 92, Lon: 1}, 4, "CFX30000+", nil}, // latitude is clipped
+		{LL{Lat: 90, Lon: 1}, 10, "CFX3X2X2+X2", nil},
+		{LL{Lat: 1, Lon: 180}, 4, "62H20000+", nil}, // longitude is normalized
+		{LL{Lat: 1, Lon: 181}, 4, "62H30000+", nil},
+		{LL{Lat: 52.52, Lon: 3600000013.4}, 10, "9F4MGCC2+22", nil}, // far outside of ±180 (10000000 turns)
+		{LL{Lat: 52.52, Lon: -3599999986.6}, 10, "9F4MGCC2+22", nil},
+		// negative tests
+		{LL{Lat: 1, Lon: 1}, 9, "", fmt.Errorf("invalid code length (2, 4, 6, 8, 10-15), length = 9")},
+		{LL{Lat: 1, Lon: 1}, 16, "", fmt.Errorf("invalid code length (2, 4, 6, 8, 10-15), length = 16")},
+		{LL{Lat: 52.52, Lon: math.Inf(1)}, 10, "", fmt.Errorf("invalid position, ll = 52.520000 +Inf")},
+		{LL{Lat: math.Inf(-1), Lon: 13.4}, 10, "", fmt.Errorf("invalid position, ll = -Inf 13.400000")},
+	}
+
+	for _, test := range tests {
+		pluscode, err := test.ll.ToPlusCode(test.length)
+		function := fmt.Sprintf("%#v.ToPlusCode(%d)", test.ll, test.length)
+		got := fmt.Sprintf("%s %v", pluscode, err)
+		want := fmt.Sprintf("%s %v", test.pluscode, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestPlusCode_Validate(t *testing.T) {
+
+	var tests = []struct {
+		pluscode PlusCode // in
+		full     bool     // out
+		short    bool     // out
+		err      error    // out
+	}{
+		// positive tests
+		{"8FWC2345+G6", true, false, nil},
+		{"8fwc2345+", true, false, nil},
+		{"8FWC2300+", true, false, nil},
+		{"2F000000+", true, false, nil},
+		{"WC2345+G6", false, true, nil},
+		{"2345+G6G", false, true, nil},
+		{"X9FF2345+G6", false, false, nil}, // valid syntax, but first latitude digit beyond 90°N
+		// negative tests
+		{"8FWC2345+G", false, false, fmt.Errorf("single digit after separator not allowed, pluscode = 8FWC2345+G")},
+		{"8FWC2_45+G6", false, false, fmt.Errorf("invalid plus code character '_', pluscode = 8FWC2_45+G6")},
+		{"8FWC2345+G6+", false, false, fmt.Errorf("plus code requires exactly one separator '+', pluscode = 8FWC2345+G6+")},
+		{"8FWC2300+G6", false, false, fmt.Errorf("invalid padding, pluscode = 8FWC2300+G6")},
+		{"8FW00000+", false, false, fmt.Errorf("invalid padding position, pluscode = 8FW00000+")},
+		{"WC2300+", false, false, fmt.Errorf("padding not allowed in short codes, pluscode = WC2300+")},
+		{"8FWC2345G6", false, false, fmt.Errorf("plus code requires exactly one separator '+', pluscode = 8FWC2345G6")},
+		{"8FWC2345G6+", false, false, fmt.Errorf("invalid separator position, pluscode = 8FWC2345G6+")},
+		{"+", false, false, fmt.Errorf("plus code too short, pluscode = +")},
+	}
+
+	for _, test := range tests {
+		err := test.pluscode.Validate()
+		function := fmt.Sprintf("%s.Validate()", test.pluscode)
+		got := fmt.Sprintf("%v %v %v", test.pluscode.IsFull(), test.pluscode.IsShort(), err)
+		want := fmt.Sprintf("%v %v %v", test.full, test.short, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestPlusCode_Bounds(t *testing.T) {
+
+	var tests = []struct {
+		pluscode PlusCode    // in
+		bbox     BoundingBox // out
+		ll       LL          // out
+		length   int         // out
+		err      error       // out
+	}{
+		// positive tests
+		{"7FG49Q00+", BoundingBox{South: 20.35, West: 2.75, North: 20.4, East: 2.8}, LL{Lat: 20.375, Lon: 2.775}, 6, nil},
+		{"7FG49QCJ+2V", BoundingBox{South: 20.37, West: 2.782125, North: 20.370125, East: 2.78225}, LL{Lat: 20.370063, Lon: 2.782188}, 10, nil},
+		{"7fg49qcj+2vx", BoundingBox{South: 20.3701, West: 2.782219, North: 20.370125, East: 2.78225}, LL{Lat: 20.370113, Lon: 2.782234}, 11, nil},
+		{"CFX3X2X2+X2", BoundingBox{South: 89.999875, West: 1, North: 90, East: 1.000125}, LL{Lat: 89.999938, Lon: 1.000062}, 10, nil},
+		// negative tests
+		{"CJ+2VX", BoundingBox{}, LL{}, 5, fmt.Errorf("short plus code (recover with reference position first), pluscode = CJ+2VX")},
+		{"X9FF2345+G6", BoundingBox{}, LL{}, 10, fmt.Errorf("plus code outside of valid range, pluscode = X9FF2345+G6")},
+	}
+
+	for _, test := range tests {
+		bbox, err := test.pluscode.Bounds()
+		function := fmt.Sprintf("%s.Bounds()", test.pluscode)
+		got := fmt.Sprintf("%s %d %v", bbox, test.pluscode.Length(), err)
+		want := fmt.Sprintf("%s %d %v", test.bbox, test.length, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		ll, err := test.pluscode.ToLL()
+		function = fmt.Sprintf("%s.ToLL()", test.pluscode)
+		got = fmt.Sprintf("%s %v", ll, err)
+		want = fmt.Sprintf("%s %v", test.ll, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestPlusCode_Shorten(t *testing.T) {
+
+	var tests = []struct {
+		pluscode PlusCode // in
+		ref      LL       // in
+		short    PlusCode // out
+		err      error    // out
+	}{
+		// positive tests
+		{"9C3W9QCJ+2VX", LL{Lat: 51.3701125, Lon: -1.217765625}, "+2VX", nil},
+		{"9C3W9QCJ+2VX", LL{Lat: 51.3708675, Lon: -1.217765625}, "CJ+2VX", nil},
+		{"9C3W9QCJ+2VX", LL{Lat: 51.3693575, Lon: -1.217765625}, "CJ+2VX", nil},
+		{"9C3W9QCJ+2VX", LL{Lat: 51.3701125, Lon: -1.218520625}, "CJ+2VX", nil},
+		{"9C3W9QCJ+2VX", LL{Lat: 51.3701125, Lon: -1.217010625}, "CJ+2VX", nil},
+		{"9C3W9QCJ+2VX", LL{Lat: 51.3852125, Lon: -1.217765625}, "9QCJ+2VX", nil},
+		{"9C3W9QCJ+2VX", LL{Lat: 40.0, Lon: -1.2}, "9C3W9QCJ+2VX", nil}, // reference too far away
+		{"9F4MGC7R+", LL{Lat: 52.51375, Lon: 13.44125}, "7R+", nil},     // at least one pair remains
+		// negative tests
+		{"9C3W9Q00+", LL{Lat: 51.37, Lon: -1.21}, "", fmt.Errorf("padded plus code cannot be shortened, pluscode = 9C3W9Q00+")},
+		{"CJ+2VX", LL{Lat: 51.37, Lon: -1.21}, "", fmt.Errorf("plus code is not a valid full code, pluscode = CJ+2VX")},
+	}
+
+	for _, test := range tests {
+		short, err := test.pluscode.Shorten(test.ref)
+		function := fmt.Sprintf("%s.Shorten(%s)", test.pluscode, test.ref)
+		got := fmt.Sprintf("%s %v", short, err)
+		want := fmt.Sprintf("%s %v", test.short, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestPlusCode_Recover(t *testing.T) {
+
+	var tests = []struct {
+		pluscode PlusCode // in
+		ref      LL       // in
+		full     PlusCode // out
+		err      error    // out
+	}{
+		// positive tests
+		{"+2VX", LL{Lat: 51.3701125, Lon: -1.217765625}, "9C3W9QCJ+2VX", nil},
+		{"CJ+2VX", LL{Lat: 51.3708675, Lon: -1.217765625}, "9C3W9QCJ+2VX", nil},
+		{"9QCJ+2VX", LL{Lat: 51.3, Lon: -1.1}, "9C3W9QCJ+2VX", nil},
+		{"22+", LL{Lat: 42.899, Lon: 9.012}, "8FJFW222+", nil}, // neighbouring area
+		{"2222+22", LL{Lat: -89.6, Lon: -179.9}, "22222222+22", nil},
+		{"9c3w9qcj+2vx", LL{Lat: 0, Lon: 0}, "9C3W9QCJ+2VX", nil}, // full code is returned unchanged
+		// negative tests
+		{"qcj+2vx", LL{Lat: 51.3, Lon: -1.1}, "", fmt.Errorf("plus code is not a valid short code, pluscode = qcj+2vx")},
+	}
+
+	for _, test := range tests {
+		full, err := test.pluscode.Recover(test.ref)
+		function := fmt.Sprintf("%s.Recover(%s)", test.pluscode, test.ref)
+		got := fmt.Sprintf("%s %v", full, err)
+		want := fmt.Sprintf("%s %v", test.full, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestPlusCode_MGRS(t *testing.T) {
+
+	var tests = []struct {
+		pluscode PlusCode // in
+		mgrs     MGRS     // out
+		utm      UTM      // out
+		back     PlusCode // out
+	}{
+		{"9F4MGC7R+2F", "33UUU9421619191", UTM{ZoneNumber: 33, ZoneLetter: 'U', Easting: 394217, Northing: 5819191}, "9F4MGC7R+2F"},
+		{"8FVC2222+22", "32TMT2397905656", UTM{ZoneNumber: 32, ZoneLetter: 'T', Easting: 423980, Northing: 5205656}, "8FVC2222+22"},
+	}
+
+	for _, test := range tests {
+		mgrs, err := test.pluscode.ToMGRS(1)
+		function := fmt.Sprintf("%s.ToMGRS(1)", test.pluscode)
+		got := fmt.Sprintf("%s %v", mgrs, err)
+		want := fmt.Sprintf("%s %v", test.mgrs, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		utm, err := test.pluscode.ToUTM()
+		function = fmt.Sprintf("%s.ToUTM()", test.pluscode)
+		got = fmt.Sprintf("%s %v", utm, err)
+		want = fmt.Sprintf("%s %v", test.utm, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		back, err := mgrs.ToPlusCode(10)
+		function = fmt.Sprintf("%s.ToPlusCode(10)", mgrs)
+		got = fmt.Sprintf("%s %v", back, err)
+		want = fmt.Sprintf("%s %v", test.back, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		back, err = utm.ToPlusCode(10)
+		function = fmt.Sprintf("%s.ToPlusCode(10)", utm)
+		got = fmt.Sprintf("%s %v", back, err)
+		want = fmt.Sprintf("%s %v", test.back, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExamplePlusCode_Recover() {
+
+	short := PlusCode("GC7R+2F")
+	full, err := short.Recover(LL{Lat: 52.52, Lon: 13.40})
+	if err != nil {
+		log.Printf("error <%v> at pluscode.Recover()", err)
+		return
+	}
+	mgrs, err := full.ToMGRS(1)
+	if err != nil {
+		log.Printf("error <%v> at pluscode.ToMGRS()", err)
+		return
+	}
+	fmt.Println(full)
+	fmt.Println(mgrs)
+
+	// Output:
+	// 9F4MGC7R+2F
+	// 33UUU9421619191
+}
@@ -0,0 +1,296 @@
+/*
+Purpose:
+- Dutch RD New (Rijksdriehoeksmeting, EPSG:28992) <-> Lon Lat
+
+Description:
+- RD coordinates (X = easting, Y = northing) in the oblique stereographic projection on the
+  Bessel 1841 ellipsoid (Amersfoort datum).
+- Two methods: rigorous oblique stereographic projection (EPSG method 9809, IOGP guidance note 7-2)
+  with Helmert datum shift, and the official approximation polynomials (RD <-> WGS84 directly).
+
+Remarks:
+- Projection parameters: latitude of origin 52°09'22.178"N, central meridian 5°23'15.5"E,
+  scale 0.9999079, false easting 155000 m, false northing 463000 m.
+- Both methods are accurate to about 1 m against RDNAPTRANS (official grid transformation, not supported).
+- The approximation polynomials are valid within the Netherlands only.
+
+Links:
+- https://epsg.io/28992
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+)
+
+// RDMethod defines the method used for the RD conversion
+type RDMethod int
+
+// RD conversion methods
+const (
+	RDStereographic RDMethod = iota // rigorous oblique stereographic projection with datum shift (default)
+	RDPolynomial                    // official approximation polynomials
+)
+
+// RD defines coordinate in Dutch RD New (Rijksdriehoeksmeting)
+type RD struct {
+	X float64 // easting
+	Y float64 // northing
+}
+
+// RD projection parameters (Amersfoort, Bessel 1841)
+const (
+	rdLat0          = 52.0 + 9.0/60 + 22.178/3600 // latitude of origin
+	rdLon0          = 5.0 + 23.0/60 + 15.5/3600   // central meridian
+	rdK0            = 0.9999079
+	rdFalseEasting  = 155000.0
+	rdFalseNorthing = 463000.0
+)
+
+// RD approximation polynomials, reference point (Amersfoort) in WGS84
+const (
+	rdPolyLat0 = 52.15517440
+	rdPolyLon0 = 5.38720621
+)
+
+// rdTerm defines a polynomial coefficient: value * a^p * b^q
+type rdTerm struct {
+	p, q  int
+	value float64
+}
+
+// WGS84 -> RD polynomial coefficients (X: R, Y: S)
+var (
+	rdR = []rdTerm{{0, 1, 190094.945}, {1, 1, -11832.228}, {2, 1, -114.221}, {0, 3, -32.391}, {1, 0, -0.705},
+		{3, 1, -2.340}, {1, 3, -0.608}, {0, 2, -0.008}, {2, 3, 0.148}}
+	rdS = []rdTerm{{1, 0, 309056.544}, {0, 2, 3638.893}, {2, 0, 73.077}, {1, 2, -157.984}, {3, 0, 59.788},
+		{0, 1, 0.433}, {2, 2, -6.439}, {1, 1, -0.032}, {0, 4, 0.092}, {1, 4, -0.054}}
+)
+
+// RD -> WGS84 polynomial coefficients (Lat: K, Lon: L, in arc seconds)
+var (
+	rdK = []rdTerm{{0, 1, 3235.65389}, {2, 0, -32.58297}, {0, 2, -0.24750}, {2, 1, -0.84978}, {0, 3, -0.06550},
+		{2, 2, -0.01709}, {1, 0, -0.00738}, {4, 0, 0.00530}, {2, 3, -0.00039}, {4, 1, 0.00033}, {1, 1, -0.00012}}
+	rdL = []rdTerm{{1, 0, 5260.52916}, {1, 1, 105.94684}, {1, 2, 2.45656}, {3, 0, -0.81885}, {1, 3, 0.05594},
+		{3, 1, -0.05607}, {0, 1, 0.01199}, {3, 2, -0.00256}, {1, 4, 0.00128}, {0, 2, 0.00022}, {2, 0, -0.00022},
+		{5, 0, 0.00026}}
+)
+
+/*
+String returns the name of the RD conversion method.
+*/
+func (method RDMethod) String() string {
+
+	switch method {
+	case RDStereographic:
+		return "RDStereographic"
+	case RDPolynomial:
+		return "RDPolynomial"
+	}
+
+	return fmt.Sprintf("RDMethod(%d)", int(method))
+}
+
+/*
+String returns stringified RD object (X and Y, rounded to meters).
+*/
+func (rd RD) String() string {
+
+	return fmt.Sprintf("%.0f %.0f", roundValue(rd.X, 1, RoundHalfUp), roundValue(rd.Y, 1, RoundHalfUp))
+}
+
+/*
+rdPolynomial evaluates the sum of value * a^p * b^q over all terms.
+*/
+func rdPolynomial(terms []rdTerm, a, b float64) float64 {
+
+	sum := 0.0
+	for _, term := range terms {
+		sum += term.value * math.Pow(a, float64(term.p)) * math.Pow(b, float64(term.q))
+	}
+
+	return sum
+}
+
+// obliqueStereographic defines the parameters of an oblique stereographic projection (EPSG method 9809)
+type obliqueStereographic struct {
+	ellipsoid     Ellipsoid
+	lat0          float64 // latitude of origin in degrees
+	lon0          float64 // longitude of origin in degrees
+	k0            float64 // scale factor at the origin
+	falseEasting  float64
+	falseNorthing float64
+}
+
+/*
+rdProjection returns the oblique stereographic parameters of RD New.
+*/
+func rdProjection() obliqueStereographic {
+
+	return obliqueStereographic{
+		ellipsoid:     EllipsoidBessel1841,
+		lat0:          rdLat0,
+		lon0:          rdLon0,
+		k0:            rdK0,
+		falseEasting:  rdFalseEasting,
+		falseNorthing: rdFalseNorthing,
+	}
+}
+
+/*
+constants returns the eccentricity, conformal sphere radius R, n, c and conformal latitude of origin chi0.
+*/
+func (os obliqueStereographic) constants() (e, R, n, c, chi0 float64) {
+
+	e2 := os.ellipsoid.E2()
+	e = math.Sqrt(e2)
+	sinLat0 := math.Sin(degToRad(os.lat0))
+	cosLat0 := math.Cos(degToRad(os.lat0))
+
+	rho0 := os.ellipsoid.A * (1 - e2) / math.Pow(1-e2*sinLat0*sinLat0, 1.5)
+	nu0 := os.ellipsoid.A / math.Sqrt(1-e2*sinLat0*sinLat0)
+	R = math.Sqrt(rho0 * nu0)
+	n = math.Sqrt(1 + e2*math.Pow(cosLat0, 4)/(1-e2))
+
+	S1 := (1 + sinLat0) / (1 - sinLat0)
+	S2 := (1 - e*sinLat0) / (1 + e*sinLat0)
+	w1 := math.Pow(S1*math.Pow(S2, e), n)
+	sinChi0 := (w1 - 1) / (w1 + 1)
+	c = (n + sinLat0) * (1 - sinChi0) / ((n - sinLat0) * (1 + sinChi0))
+	w2 := c * w1
+	chi0 = math.Asin((w2 - 1) / (w2 + 1))
+
+	return e, R, n, c, chi0
+}
+
+/*
+forward projects latitude and longitude (degrees) to easting and northing (meters).
+*/
+func (os obliqueStereographic) forward(lat, lon float64) (float64, float64) {
+
+	e, R, n, c, chi0 := os.constants()
+	lon0 := degToRad(os.lon0)
+	sinLat := math.Sin(degToRad(lat))
+
+	// conformal latitude and longitude
+	dLambda := n * (degToRad(lon) - lon0)
+	Sa := (1 + sinLat) / (1 - sinLat)
+	Sb := (1 - e*sinLat) / (1 + e*sinLat)
+	w := c * math.Pow(Sa*math.Pow(Sb, e), n)
+	chi := math.Asin((w - 1) / (w + 1))
+
+	B := 1 + math.Sin(chi)*math.Sin(chi0) + math.Cos(chi)*math.Cos(chi0)*math.Cos(dLambda)
+	easting := os.falseEasting + 2*R*os.k0*math.Cos(chi)*math.Sin(dLambda)/B
+	northing := os.falseNorthing + 2*R*os.k0*(math.Sin(chi)*math.Cos(chi0)-math.Cos(chi)*math.Sin(chi0)*math.Cos(dLambda))/B
+
+	return easting, northing
+}
+
+/*
+inverse projects easting and northing (meters) to latitude and longitude (degrees).
+*/
+func (os obliqueStereographic) inverse(easting, northing float64) (float64, float64) {
+
+	e, R, n, c, chi0 := os.constants()
+	e2 := e * e
+	dE := easting - os.falseEasting
+	dN := northing - os.falseNorthing
+
+	g := 2 * R * os.k0 * math.Tan(math.Pi/4-chi0/2)
+	h := 4*R*os.k0*math.Tan(chi0) + g
+	i := math.Atan(dE / (h + dN))
+	j := math.Atan(dE/(g-dN)) - i
+	chi := chi0 + 2*math.Atan((dN-dE*math.Tan(j/2))/(2*R*os.k0))
+	lon := degToRad(os.lon0) + (j+2*i)/n
+
+	// isometric latitude -> geodetic latitude (iteration)
+	psi := 0.5 * math.Log((1+math.Sin(chi))/(c*(1-math.Sin(chi)))) / n
+	lat := 2*math.Atan(math.Exp(psi)) - math.Pi/2
+	for k := 0; k < 20; k++ {
+		sinLat := math.Sin(lat)
+		psiLat := math.Log(math.Tan(lat/2+math.Pi/4) * math.Pow((1-e*sinLat)/(1+e*sinLat), e/2))
+		next := lat - (psiLat-psi)*math.Cos(lat)*(1-e2*sinLat*sinLat)/(1-e2)
+		if math.Abs(next-lat) < 1e-14 {
+			lat = next
+			break
+		}
+		lat = next
+	}
+
+	return radToDeg(lat), radToDeg(lon)
+}
+
+/*
+ToRD converts Lon Lat (WGS84 datum) to RD New (rigorous oblique stereographic projection).
+*/
+func (ll LL) ToRD() (RD, error) {
+
+	return ll.ToRDMethod(RDStereographic)
+}
+
+/*
+ToRDMethod converts Lon Lat (WGS84 datum) to RD New.
+method holds the conversion method (RDStereographic or RDPolynomial).
+*/
+func (ll LL) ToRDMethod(method RDMethod) (RD, error) {
+
+	if ll.Lon < -180 || ll.Lon > 180 {
+		return RD{}, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if ll.Lat < -90 || ll.Lat > 90 {
+		return RD{}, fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	rd := RD{}
+	switch method {
+	case RDStereographic:
+		llAmersfoort := ll.Transform(WGS84, Amersfoort)
+		rd.X, rd.Y = rdProjection().forward(llAmersfoort.Lat, llAmersfoort.Lon)
+	case RDPolynomial:
+		dLat := 0.36 * (ll.Lat - rdPolyLat0)
+		dLon := 0.36 * (ll.Lon - rdPolyLon0)
+		rd.X = rdFalseEasting + rdPolynomial(rdR, dLat, dLon)
+		rd.Y = rdFalseNorthing + rdPolynomial(rdS, dLat, dLon)
+	default:
+		return RD{}, fmt.Errorf("invalid rd method, method = %v", method)
+	}
+
+	return rd, nil
+}
+
+/*
+ToLL converts RD New to Lon Lat (WGS84 datum, rigorous oblique stereographic projection).
+*/
+func (rd RD) ToLL() (LL, error) {
+
+	return rd.ToLLMethod(RDStereographic)
+}
+
+/*
+ToLLMethod converts RD New to Lon Lat (WGS84 datum).
+method holds the conversion method (RDStereographic or RDPolynomial).
+*/
+func (rd RD) ToLLMethod(method RDMethod) (LL, error) {
+
+	if math.IsNaN(rd.X) || math.IsInf(rd.X, 0) || math.IsNaN(rd.Y) || math.IsInf(rd.Y, 0) {
+		return LL{}, fmt.Errorf("invalid rd coordinate, rd = %s", rd)
+	}
+
+	ll := LL{}
+	switch method {
+	case RDStereographic:
+		ll.Lat, ll.Lon = rdProjection().inverse(rd.X, rd.Y)
+		ll = ll.Transform(Amersfoort, WGS84)
+	case RDPolynomial:
+		dX := (rd.X - rdFalseEasting) * 1e-5
+		dY := (rd.Y - rdFalseNorthing) * 1e-5
+		ll.Lat = rdPolyLat0 + rdPolynomial(rdK, dX, dY)/3600
+		ll.Lon = rdPolyLon0 + rdPolynomial(rdL, dX, dY)/3600
+	default:
+		return LL{}, fmt.Errorf("invalid rd method, method = %v", method)
+	}
+
+	return ll, nil
+}
@@ -0,0 +1,121 @@
+/*
+Purpose:
+- Dutch RD New (Rijksdriehoeksmeting, EPSG:28992) <-> Lon Lat
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"testing"
+)
+
+func TestLL_ToRDMethod(t *testing.T) {
+
+	var tests = []struct {
+		ll     LL       // in
+		method RDMethod // in
+		rd     RD       // out
+		err    error    // out
+	}{
+		// positive tests
+		{LL{Lat: 52.3731, Lon: 4.8922}, RDStereographic, RD{X: 121290.514, Y: 487362.277}, nil}, // Amsterdam
+		{LL{Lat: 52.3731, Lon: 4.8922}, RDPolynomial, RD{X: 121290.330, Y: 487362.044}, nil},
+		{LL{Lat: 51.9225, Lon: 4.47917}, RDStereographic, RD{X: 92536.937, Y: 437503.390}, nil}, // Rotterdam
+		{LL{Lat: 51.9225, Lon: 4.47917}, RDPolynomial, RD{X: 92536.752, Y: 437503.158}, nil},
+		{LL{Lat: 53.2194, Lon: 6.5665}, RDStereographic, RD{X: 233769.705, Y: 582065.417}, nil},      // Groningen
+		{LL{Lat: 52.15517440, Lon: 5.38720621}, RDPolynomial, RD{X: 155000.000, Y: 463000.000}, nil}, // Amersfoort
+		// negative tests
+		{LL{Lat: 52.0, Lon: 185.0}, RDStereographic, RD{}, fmt.Errorf("invalid longitude, lon = 185")},
+		{LL{Lat: 52.0, Lon: 5.0}, RDMethod(7), RD{}, fmt.Errorf("invalid rd method, method = RDMethod(7)")},
+	}
+
+	for _, test := range tests {
+		rd, err := test.ll.ToRDMethod(test.method)
+		function := fmt.Sprintf("%#v.ToRDMethod(%s)", test.ll, test.method)
+		got := fmt.Sprintf("%.3f %.3f %v", rd.X, rd.Y, err)
+		want := fmt.Sprintf("%.3f %.3f %v", test.rd.X, test.rd.Y, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		// round trip
+		if err == nil {
+			ll, err := rd.ToLLMethod(test.method)
+			got = fmt.Sprintf("%s %v", ll, err)
+			want = fmt.Sprintf("%s %v", test.ll, nil)
+			if got != want {
+				t.Errorf("\n%#v.ToLLMethod(%s) -> %s != %s\n", rd, test.method, got, want)
+			}
+		}
+	}
+}
+
+func TestObliqueStereographic(t *testing.T) {
+
+	// EPSG guidance note 7-2, Amersfoort / RD New example (Amersfoort datum)
+	easting, northing := rdProjection().forward(53.0, 6.0)
+	got := fmt.Sprintf("%.3f %.3f", easting, northing)
+	want := "196105.283 557057.739"
+	if got != want {
+		t.Errorf("\nrdProjection().forward(53, 6) -> %s != %s\n", got, want)
+	}
+
+	lat, lon := rdProjection().inverse(196105.283, 557057.739)
+	got = fmt.Sprintf("%.8f %.8f", lat, lon)
+	want = "53.00000000 6.00000000"
+	if got != want {
+		t.Errorf("\nrdProjection().inverse(196105.283, 557057.739) -> %s != %s\n", got, want)
+	}
+}
+
+func TestRD_ToLL(t *testing.T) {
+
+	var tests = []struct {
+		rd  RD    // in
+		ll  LL    // out
+		err error // out
+	}{
+		// positive tests
+		{RD{X: 155000, Y: 463000}, LL{Lat: 52.155172, Lon: 5.387204}, nil},
+		{RD{X: 121290, Y: 487362}, LL{Lat: 52.373097, Lon: 4.892192}, nil},
+		// negative tests
+		{RD{X: 155000, Y: math.Inf(1)}, LL{}, fmt.Errorf("invalid rd coordinate, rd = 155000 +Inf")},
+	}
+
+	for _, test := range tests {
+		ll, err := test.rd.ToLL()
+		function := fmt.Sprintf("%#v.ToLL()", test.rd)
+		got := fmt.Sprintf("%s %v", ll, err)
+		want := fmt.Sprintf("%s %v", test.ll, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleRD_ToLL() {
+
+	rd := RD{X: 121290, Y: 487362}
+	ll, err := rd.ToLL()
+	if err != nil {
+		log.Printf("error <%v> at rd.ToLL()", err)
+		return
+	}
+	mgrs, err := ll.ToMGRS(10)
+	if err != nil {
+		log.Printf("error <%v> at ll.ToMGRS()", err)
+		return
+	}
+	fmt.Println(ll)
+	fmt.Println(mgrs)
+
+	// Output:
+	// 52.373097 4.892192
+	// 31UFU28810422
+}
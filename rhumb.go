@@ -0,0 +1,233 @@
+/*
+Purpose:
+- Rhumb line (loxodrome) distance and constant bearing between two positions on the ellipsoid
+- Destination and midpoint along a rhumb line
+
+Description:
+- A rhumb line crosses all meridians at the same angle, it is a straight line on a Mercator chart.
+  Ships steer rhumb lines (constant compass course), they are longer than the geodesic.
+- Latitudes are handled with the rectifying latitude (meridian distance) and the isometric latitude
+  (Mercator latitude) of the WGS84 ellipsoid.
+- Rhumb lines always take the shorter way in longitude (less than 180° east or west).
+- Results are LL positions, use ToUTM() or ToMGRS() for grid coordinates.
+
+Remarks:
+- Meridian distance series of 4th order in the third flattening (accuracy better than 1 mm).
+- Bearings in degrees (0-360, clockwise from true north).
+- A rhumb line with a bearing other than east or west ends at the pole, destinations beyond the pole are rejected.
+
+Links:
+- https://en.wikipedia.org/wiki/Rhumb_line
+- https://geographiclib.sourceforge.io/C++/doc/classGeographicLib_1_1Rhumb.html
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+)
+
+// Rhumb defines the rhumb line (loxodrome) between two positions
+type Rhumb struct {
+	Distance float64 // distance along the rhumb line in meters
+	Bearing  float64 // constant bearing (degrees)
+}
+
+// rhumb holds the ellipsoid dependent parameters of the rhumb line calculations
+type rhumb struct {
+	ellipsoid Ellipsoid
+	ks        kruegerSeries
+	n         float64
+}
+
+/*
+String returns stringified Rhumb object (distance in meters, bearing in degrees).
+*/
+func (r Rhumb) String() string {
+
+	return fmt.Sprintf("%.3f %.6f", r.Distance, r.Bearing)
+}
+
+/*
+RhumbTo returns distance and constant bearing of the rhumb line between two positions (WGS84 ellipsoid).
+other holds the end point.
+*/
+func (ll LL) RhumbTo(other LL) (Rhumb, error) {
+
+	if err := ll.validateRhumb(); err != nil {
+		return Rhumb{}, err
+	}
+	if err := other.validateRhumb(); err != nil {
+		return Rhumb{}, err
+	}
+
+	distance, bearing := newRhumb(EllipsoidWGS84).inverse(ll.Lat, ll.Lon, other.Lat, other.Lon)
+
+	return Rhumb{Distance: distance, Bearing: bearing}, nil
+}
+
+/*
+RhumbDestination returns the destination of a rhumb line with constant bearing (WGS84 ellipsoid).
+bearing holds the constant bearing in degrees (clockwise from true north).
+distance holds the distance in meters.
+*/
+func (ll LL) RhumbDestination(bearing, distance float64) (LL, error) {
+
+	if err := ll.validateRhumb(); err != nil {
+		return LL{}, err
+	}
+	if math.IsNaN(bearing) || math.IsInf(bearing, 0) {
+		return LL{}, fmt.Errorf("invalid bearing, bearing = %v", bearing)
+	}
+	if math.IsNaN(distance) || math.IsInf(distance, 0) {
+		return LL{}, fmt.Errorf("invalid distance, distance = %v", distance)
+	}
+
+	lat2, lon2, err := newRhumb(EllipsoidWGS84).direct(ll.Lat, ll.Lon, bearing, distance)
+	if err != nil {
+		return LL{}, err
+	}
+
+	return LL{Lat: lat2, Lon: lon2}, nil
+}
+
+/*
+RhumbMidpoint returns the point halfway along the rhumb line between two positions (WGS84 ellipsoid).
+other holds the end point.
+*/
+func (ll LL) RhumbMidpoint(other LL) (LL, error) {
+
+	r, err := ll.RhumbTo(other)
+	if err != nil {
+		return LL{}, err
+	}
+
+	return ll.RhumbDestination(r.Bearing, r.Distance/2)
+}
+
+/*
+validateRhumb checks the position for rhumb line calculations.
+*/
+func (ll LL) validateRhumb() error {
+
+	if math.IsNaN(ll.Lon) || math.IsInf(ll.Lon, 0) {
+		return fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if !(ll.Lat >= -90 && ll.Lat <= 90) {
+		return fmt.Errorf("invalid latitude, lat = %v", ll.Lat)
+	}
+
+	return nil
+}
+
+/*
+newRhumb calculates the rhumb line parameters for the given ellipsoid.
+*/
+func newRhumb(ellipsoid Ellipsoid) rhumb {
+
+	return rhumb{ellipsoid: ellipsoid, ks: newKruegerSeries(ellipsoid), n: ellipsoid.N()}
+}
+
+/*
+inverse returns distance (meters) and constant bearing (degrees) of the rhumb line between two positions.
+*/
+func (r rhumb) inverse(lat1, lon1, lat2, lon2 float64) (float64, float64) {
+
+	mu1 := r.rectifyingLat(degToRad(lat1))
+	mu2 := r.rectifyingLat(degToRad(lat2))
+	dlon := degToRad(normalizeLon(lon2 - lon1))
+
+	// meridian distance and parallel distance (stretched to the mean latitude of the rhumb line)
+	dm := r.ks.A * (mu2 - mu1)
+	dp := dlon / r.scale(lat1, lat2, mu1, mu2)
+
+	distance := math.Hypot(dm, dp)
+	bearing := azimuth360(radToDeg(math.Atan2(dp, dm)))
+
+	return distance, bearing
+}
+
+/*
+direct returns the destination (degrees) of a rhumb line with constant bearing (degrees) and distance (meters).
+*/
+func (r rhumb) direct(lat1, lon1, bearing, distance float64) (float64, float64, error) {
+
+	sinBearing, cosBearing := sincosd(bearing)
+
+	mu1 := r.rectifyingLat(degToRad(lat1))
+	mu2 := mu1 + distance*cosBearing/r.ks.A
+	if math.Abs(mu2) > math.Pi/2*(1+1e-15) {
+		return 0, 0, fmt.Errorf("rhumb line crosses the pole, bearing = %v, distance = %v", bearing, distance)
+	}
+	mu2 = math.Max(-math.Pi/2, math.Min(math.Pi/2, mu2))
+
+	lat2 := radToDeg(r.geodeticLat(mu2))
+	dlon := distance * sinBearing * r.scale(lat1, lat2, mu1, mu2)
+
+	return lat2, normalizeLon(lon1 + radToDeg(dlon)), nil
+}
+
+/*
+scale returns the change of longitude (radians) per meter of parallel distance between two latitudes
+(isometric latitude difference divided by meridian distance). Short meridian differences use the mean
+of 1/(N*cos(lat)) (Simpson's rule), this avoids the cancellation of the isometric latitude difference.
+*/
+func (r rhumb) scale(lat1, lat2, mu1, mu2 float64) float64 {
+
+	if math.Abs(mu2-mu1) > 1e-3 {
+		return (r.isometricLat(lat2) - r.isometricLat(lat1)) / (r.ks.A * (mu2 - mu1))
+	}
+
+	latMid := radToDeg(r.geodeticLat((mu1 + mu2) / 2))
+	return (r.parallelScale(lat1) + 4*r.parallelScale(latMid) + r.parallelScale(lat2)) / 6
+}
+
+/*
+parallelScale returns the change of longitude (radians) per meter along the parallel of the latitude (degrees).
+*/
+func (r rhumb) parallelScale(lat float64) float64 {
+
+	_, cosLat := sincosd(lat)
+
+	return 1 / (r.ellipsoid.RadiusOfCurvature(lat) * math.Max(cosLat, geodesicTiny))
+}
+
+/*
+isometricLat returns the isometric latitude (Mercator latitude, radians) of the latitude (degrees).
+*/
+func (r rhumb) isometricLat(lat float64) float64 {
+
+	lat = math.Max(-90, math.Min(90, lat))
+	sinLat, cosLat := sincosd(lat)
+
+	return math.Asinh(r.ks.conformalTau(sinLat / math.Max(cosLat, geodesicTiny)))
+}
+
+/*
+rectifyingLat returns the rectifying latitude (meridian distance divided by A, radians) of the latitude (radians).
+*/
+func (r rhumb) rectifyingLat(phi float64) float64 {
+
+	n := r.n
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+
+	return phi - (3*n/2-9*n3/16)*math.Sin(2*phi) + (15*n2/16-15*n4/32)*math.Sin(4*phi) -
+		35*n3/48*math.Sin(6*phi) + 315*n4/512*math.Sin(8*phi)
+}
+
+/*
+geodeticLat returns the latitude (radians) of the rectifying latitude (radians).
+*/
+func (r rhumb) geodeticLat(mu float64) float64 {
+
+	n := r.n
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+
+	return mu + (3*n/2-27*n3/32)*math.Sin(2*mu) + (21*n2/16-55*n4/32)*math.Sin(4*mu) +
+		151*n3/96*math.Sin(6*mu) + 1097*n4/512*math.Sin(8*mu)
+}
@@ -0,0 +1,133 @@
+/*
+Purpose:
+- Rhumb line (loxodrome) distance and constant bearing between two positions on the ellipsoid
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"testing"
+)
+
+func TestLL_RhumbTo(t *testing.T) {
+
+	var tests = []struct {
+		from  LL    // in
+		to    LL    // in
+		rhumb Rhumb // out
+		err   error // out
+	}{
+		// positive tests (reference value GeographicLib RhumbSolve)
+		{LL{Lat: 40.6, Lon: -73.8}, LL{Lat: 51.6, Lon: -0.5}, Rhumb{Distance: 5771083.383, Bearing: 77.768390}, nil},
+		{LL{Lat: 50, Lon: 0}, LL{Lat: 50, Lon: 10}, Rhumb{Distance: 716957.536, Bearing: 90}, nil},                    // parallel
+		{LL{Lat: 50, Lon: 0}, LL{Lat: 50.0000001, Lon: 10}, Rhumb{Distance: 716957.535, Bearing: 89.999999}, nil},     // nearly parallel
+		{LL{Lat: 0, Lon: 170}, LL{Lat: 10, Lon: -170}, Rhumb{Distance: 2475827.978, Bearing: 63.470367}, nil},         // antimeridian
+		{LL{Lat: -33.9, Lon: 18.4}, LL{Lat: -34, Lon: 151.2}, Rhumb{Distance: 12275885.248, Bearing: 90.051771}, nil}, // Cape Town - Sydney
+		{LL{Lat: 60, Lon: 5}, LL{Lat: 59.99, Lon: 5.001}, Rhumb{Distance: 1115.519, Bearing: 177.132346}, nil},
+		{LL{Lat: 90, Lon: 0}, LL{Lat: -90, Lon: 0}, Rhumb{Distance: 20003931.459, Bearing: 180}, nil}, // meridian, pole to pole
+		{LL{Lat: 10, Lon: 20}, LL{Lat: 10, Lon: 20}, Rhumb{Distance: 0, Bearing: 0}, nil},
+		// negative tests
+		{LL{Lat: 91, Lon: 0}, LL{Lat: 0, Lon: 0}, Rhumb{}, fmt.Errorf("invalid latitude, lat = 91")},
+		{LL{Lat: 0, Lon: 0}, LL{Lat: 0, Lon: math.NaN()}, Rhumb{}, fmt.Errorf("invalid longitude, lon = NaN")},
+	}
+
+	for _, test := range tests {
+		rhumb, err := test.from.RhumbTo(test.to)
+		function := fmt.Sprintf("%#v.RhumbTo(%#v)", test.from, test.to)
+		got := fmt.Sprintf("%s %v", rhumb, err)
+		want := fmt.Sprintf("%s %v", test.rhumb, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_RhumbDestination(t *testing.T) {
+
+	var tests = []struct {
+		from     LL      // in
+		bearing  float64 // in
+		distance float64 // in
+		to       LL      // out
+		err      error   // out
+	}{
+		// positive tests
+		{LL{Lat: 40.6, Lon: -73.8}, 77.7683897103, 5771083.3833279, LL{Lat: 51.6, Lon: -0.5}, nil},
+		{LL{Lat: 50, Lon: 10}, 90, 1000000, LL{Lat: 50, Lon: 23.947827}, nil},
+		{LL{Lat: 0, Lon: 0}, 270, 100000, LL{Lat: 0, Lon: -0.898315}, nil},
+		{LL{Lat: 0, Lon: 170}, 63.4703668851, 2475827.977759, LL{Lat: 10, Lon: -170}, nil}, // antimeridian
+		{LL{Lat: 90, Lon: 0}, 180, 1000, LL{Lat: 89.991047, Lon: 0}, nil},
+		// negative tests
+		{LL{Lat: 50, Lon: 10}, 45, 10000000, LL{}, fmt.Errorf("rhumb line crosses the pole, bearing = 45, distance = 1e+07")},
+		{LL{Lat: 50, Lon: 10}, 0, 4500000, LL{}, fmt.Errorf("rhumb line crosses the pole, bearing = 0, distance = 4.5e+06")},
+		{LL{Lat: 50, Lon: 10}, math.NaN(), 1000, LL{}, fmt.Errorf("invalid bearing, bearing = NaN")},
+		{LL{Lat: 50, Lon: 10}, 90, math.Inf(1), LL{}, fmt.Errorf("invalid distance, distance = +Inf")},
+	}
+
+	for _, test := range tests {
+		to, err := test.from.RhumbDestination(test.bearing, test.distance)
+		function := fmt.Sprintf("%#v.RhumbDestination(%v, %v)", test.from, test.bearing, test.distance)
+		got := fmt.Sprintf("%.6f %.6f %v", to.Lat, to.Lon, err)
+		want := fmt.Sprintf("%.6f %.6f %v", test.to.Lat, test.to.Lon, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_RhumbMidpoint(t *testing.T) {
+
+	var tests = []struct {
+		from     LL    // in
+		to       LL    // in
+		midpoint LL    // out
+		err      error // out
+	}{
+		// positive tests
+		{LL{Lat: 40.6, Lon: -73.8}, LL{Lat: 51.6, Lon: -0.5}, LL{Lat: 46.102650, Lon: -38.979433}, nil},
+		{LL{Lat: 0, Lon: 170}, LL{Lat: 10, Lon: -170}, LL{Lat: 5.000379, Lon: 179.962004}, nil},
+		{LL{Lat: 50, Lon: 0}, LL{Lat: 50, Lon: 10}, LL{Lat: 50, Lon: 5}, nil},
+		{LL{Lat: -10, Lon: -170}, LL{Lat: -10, Lon: 170}, LL{Lat: -10, Lon: -180}, nil},
+		// negative tests
+		{LL{Lat: 0, Lon: 0}, LL{Lat: -90.5, Lon: 0}, LL{}, fmt.Errorf("invalid latitude, lat = -90.5")},
+	}
+
+	for _, test := range tests {
+		midpoint, err := test.from.RhumbMidpoint(test.to)
+		function := fmt.Sprintf("%#v.RhumbMidpoint(%#v)", test.from, test.to)
+		got := fmt.Sprintf("%.6f %.6f %v", midpoint.Lat, midpoint.Lon, err)
+		want := fmt.Sprintf("%.6f %.6f %v", test.midpoint.Lat, test.midpoint.Lon, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleLL_RhumbDestination() {
+
+	// steer 045° for 50 km from Kiel
+	position, err := LL{Lat: 54.3233, Lon: 10.1228}.RhumbDestination(45, 50000)
+	if err != nil {
+		log.Printf("error <%v> at ll.RhumbDestination()", err)
+		return
+	}
+	mgrs, err := position.ToMGRS(10)
+	if err != nil {
+		log.Printf("error <%v> at ll.ToMGRS()", err)
+		return
+	}
+	fmt.Println(position)
+	fmt.Println(position.ToUTM())
+	fmt.Println(mgrs)
+
+	// Output:
+	// 54.640918 10.668278
+	// 32U 607663 6056113
+	// 32UPF07665611
+}
@@ -0,0 +1,331 @@
+/*
+Purpose:
+- Transverse Mercator projection core
+
+Description:
+- Forward and inverse transverse Mercator projection with two selectable engines:
+  Krüger n-series (6th order, Karney 2011) and the classic USGS/Snyder series.
+
+Remarks:
+- The Krüger series is accurate to a few nanometers within 3900 km of the central meridian.
+- The Snyder series is accurate to about a millimeter within a UTM zone, but degrades quickly
+  far from the central meridian.
+
+Links:
+- https://arxiv.org/abs/1002.1417 (C. F. F. Karney, Transverse Mercator with an accuracy of a few nanometers)
+- https://pubs.usgs.gov/pp/1395/report.pdf
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+)
+
+// TMEngine defines the series used for the transverse Mercator projection
+type TMEngine int
+
+// transverse Mercator engines
+const (
+	Krueger TMEngine = iota // Krüger n-series, 6th order (default)
+	Snyder                  // USGS/Snyder series
+)
+
+/*
+String returns the name of the transverse Mercator engine.
+*/
+func (engine TMEngine) String() string {
+
+	switch engine {
+	case Krueger:
+		return "Krueger"
+	case Snyder:
+		return "Snyder"
+	}
+
+	return fmt.Sprintf("TMEngine(%d)", int(engine))
+}
+
+// transverseMercator defines the parameters of a transverse Mercator projection
+type transverseMercator struct {
+	ellipsoid     Ellipsoid
+	engine        TMEngine
+	lat0          float64 // latitude of origin in degrees (0 = equator)
+	lon0          float64 // central meridian in degrees
+	k0            float64 // scale factor on the central meridian
+	falseEasting  float64
+	falseNorthing float64
+}
+
+/*
+forward projects Lon Lat (degrees) to easting and northing.
+*/
+func (tm transverseMercator) forward(lat, lon float64) (float64, float64) {
+
+	var x, y float64
+	if tm.engine == Snyder {
+		x, y = snyderForward(tm.ellipsoid, tm.k0, lat, lon-tm.lon0)
+	} else {
+		x, y = kruegerForward(tm.ellipsoid, tm.k0, lat, lon-tm.lon0)
+	}
+
+	return x + tm.falseEasting, y - tm.originNorthing() + tm.falseNorthing
+}
+
+/*
+inverse projects easting and northing to Lon Lat (degrees).
+*/
+func (tm transverseMercator) inverse(easting, northing float64) (float64, float64) {
+
+	x := easting - tm.falseEasting
+	y := northing - tm.falseNorthing + tm.originNorthing()
+
+	var lat, dlon float64
+	if tm.engine == Snyder {
+		lat, dlon = snyderInverse(tm.ellipsoid, tm.k0, x, y)
+	} else {
+		lat, dlon = kruegerInverse(tm.ellipsoid, tm.k0, x, y)
+	}
+
+	return lat, tm.lon0 + dlon
+}
+
+/*
+convergenceScale returns meridian convergence (degrees) and point scale factor at Lon Lat (degrees),
+always calculated with the Krüger series.
+*/
+func (tm transverseMercator) convergenceScale(lat, lon float64) (float64, float64) {
+
+	return kruegerConvergenceScale(tm.ellipsoid, tm.k0, lat, lon-tm.lon0)
+}
+
+/*
+originNorthing returns the (scaled) meridian distance from the equator to the latitude of origin.
+*/
+func (tm transverseMercator) originNorthing() float64 {
+
+	if tm.lat0 == 0 {
+		return 0
+	}
+
+	if tm.engine == Snyder {
+		_, y := snyderForward(tm.ellipsoid, tm.k0, tm.lat0, 0)
+		return y
+	}
+	_, y := kruegerForward(tm.ellipsoid, tm.k0, tm.lat0, 0)
+	return y
+}
+
+// kruegerSeries holds the ellipsoid dependent coefficients of the Krüger series
+type kruegerSeries struct {
+	e     float64    // first eccentricity
+	A     float64    // 2*pi*A is the circumference of a meridian
+	alpha [7]float64 // forward coefficients (index 1-6)
+	beta  [7]float64 // inverse coefficients (index 1-6)
+}
+
+/*
+newKruegerSeries calculates the Krüger series coefficients (6th order in n) for the given ellipsoid.
+*/
+func newKruegerSeries(ellipsoid Ellipsoid) kruegerSeries {
+
+	n := ellipsoid.N()
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	n5 := n4 * n
+	n6 := n5 * n
+
+	ks := kruegerSeries{}
+	ks.e = math.Sqrt(ellipsoid.E2())
+	ks.A = ellipsoid.A / (1 + n) * (1 + n2/4 + n4/64 + n6/256)
+
+	ks.alpha[1] = n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180 - 127*n5/288 + 7891*n6/37800
+	ks.alpha[2] = 13*n2/48 - 3*n3/5 + 557*n4/1440 + 281*n5/630 - 1983433*n6/1935360
+	ks.alpha[3] = 61*n3/240 - 103*n4/140 + 15061*n5/26880 + 167603*n6/181440
+	ks.alpha[4] = 49561*n4/161280 - 179*n5/168 + 6601661*n6/7257600
+	ks.alpha[5] = 34729*n5/80640 - 3418889*n6/1995840
+	ks.alpha[6] = 212378941 * n6 / 319334400
+
+	ks.beta[1] = n/2 - 2*n2/3 + 37*n3/96 - n4/360 - 81*n5/512 + 96199*n6/604800
+	ks.beta[2] = n2/48 + n3/15 - 437*n4/1440 + 46*n5/105 - 1118711*n6/3870720
+	ks.beta[3] = 17*n3/480 - 37*n4/840 - 209*n5/4480 + 5569*n6/90720
+	ks.beta[4] = 4397*n4/161280 - 11*n5/504 - 830251*n6/7257600
+	ks.beta[5] = 4583*n5/161280 - 108847*n6/3991680
+	ks.beta[6] = 20648693 * n6 / 638668800
+
+	return ks
+}
+
+/*
+conformalTau converts tan(latitude) to tan(conformal latitude).
+*/
+func (ks kruegerSeries) conformalTau(tau float64) float64 {
+
+	sigma := math.Sinh(ks.e * math.Atanh(ks.e*tau/math.Sqrt(1+tau*tau)))
+	return tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+}
+
+/*
+geodeticTau converts tan(conformal latitude) to tan(latitude) (Newton iteration).
+*/
+func (ks kruegerSeries) geodeticTau(taup float64) float64 {
+
+	e2 := ks.e * ks.e
+	tau := taup
+	for i := 0; i < 10; i++ {
+		taui := ks.conformalTau(tau)
+		dtau := (taup - taui) / math.Sqrt(1+taui*taui) * (1 + (1-e2)*tau*tau) / ((1 - e2) * math.Sqrt(1+tau*tau))
+		tau += dtau
+		if math.Abs(dtau) < 1e-12 {
+			break
+		}
+	}
+
+	return tau
+}
+
+/*
+kruegerForward projects latitude and longitude difference (degrees) to x and y (meters) with the Krüger series.
+*/
+func kruegerForward(ellipsoid Ellipsoid, k0, lat, dlon float64) (float64, float64) {
+
+	ks := newKruegerSeries(ellipsoid)
+
+	latRad := degToRad(lat)
+	dlonRad := degToRad(dlon)
+	cosLon := math.Cos(dlonRad)
+	sinLon := math.Sin(dlonRad)
+
+	taup := ks.conformalTau(math.Tan(latRad))
+	xip := math.Atan2(taup, cosLon)
+	etap := math.Asinh(sinLon / math.Sqrt(taup*taup+cosLon*cosLon))
+
+	xi := xip
+	eta := etap
+	for j := 1; j <= 6; j++ {
+		xi += ks.alpha[j] * math.Sin(2*float64(j)*xip) * math.Cosh(2*float64(j)*etap)
+		eta += ks.alpha[j] * math.Cos(2*float64(j)*xip) * math.Sinh(2*float64(j)*etap)
+	}
+
+	return k0 * ks.A * eta, k0 * ks.A * xi
+}
+
+/*
+kruegerConvergenceScale returns meridian convergence (degrees, grid north clockwise from true north) and
+point scale factor at latitude and longitude difference (degrees) with the Krüger series.
+*/
+func kruegerConvergenceScale(ellipsoid Ellipsoid, k0, lat, dlon float64) (float64, float64) {
+
+	ks := newKruegerSeries(ellipsoid)
+	e2 := ellipsoid.E2()
+
+	latRad := degToRad(lat)
+	dlonRad := degToRad(dlon)
+	cosLon := math.Cos(dlonRad)
+	sinLon := math.Sin(dlonRad)
+	cosLat := math.Cos(latRad)
+	tau := math.Tan(latRad)
+
+	taup := ks.conformalTau(tau)
+	xip := math.Atan2(taup, cosLon)
+	etap := math.Asinh(sinLon / math.Sqrt(taup*taup+cosLon*cosLon))
+
+	// convergence and scale of the conformal sphere
+	gamma := math.Atan2(sinLon*taup, cosLon*math.Sqrt(1+taup*taup))
+	k := math.Sqrt(1-e2*(1-cosLat*cosLat)) * math.Sqrt(1+tau*tau) / math.Sqrt(taup*taup+cosLon*cosLon)
+
+	// derivative of the series (p' and q')
+	p := 1.0
+	q := 0.0
+	for j := 1; j <= 6; j++ {
+		p += 2 * float64(j) * ks.alpha[j] * math.Cos(2*float64(j)*xip) * math.Cosh(2*float64(j)*etap)
+		q += 2 * float64(j) * ks.alpha[j] * math.Sin(2*float64(j)*xip) * math.Sinh(2*float64(j)*etap)
+	}
+	gamma += math.Atan2(q, p)
+	k *= ks.A / ellipsoid.A * math.Hypot(p, q)
+
+	return radToDeg(gamma), k0 * k
+}
+
+/*
+kruegerInverse projects x and y (meters) to latitude and longitude difference (degrees) with the Krüger series.
+*/
+func kruegerInverse(ellipsoid Ellipsoid, k0, x, y float64) (float64, float64) {
+
+	ks := newKruegerSeries(ellipsoid)
+
+	xi := y / (k0 * ks.A)
+	eta := x / (k0 * ks.A)
+
+	xip := xi
+	etap := eta
+	for j := 1; j <= 6; j++ {
+		xip -= ks.beta[j] * math.Sin(2*float64(j)*xi) * math.Cosh(2*float64(j)*eta)
+		etap -= ks.beta[j] * math.Cos(2*float64(j)*xi) * math.Sinh(2*float64(j)*eta)
+	}
+
+	sinhEtap := math.Sinh(etap)
+	sinXip := math.Sin(xip)
+	cosXip := math.Cos(xip)
+
+	taup := sinXip / math.Sqrt(sinhEtap*sinhEtap+cosXip*cosXip)
+	tau := ks.geodeticTau(taup)
+
+	return radToDeg(math.Atan(tau)), radToDeg(math.Atan2(sinhEtap, cosXip))
+}
+
+/*
+snyderForward projects latitude and longitude difference (degrees) to x and y (meters) with the USGS/Snyder series.
+*/
+func snyderForward(ellipsoid Ellipsoid, k0, lat, dlon float64) (float64, float64) {
+
+	a := ellipsoid.A
+	eccSquared := ellipsoid.E2()
+	LatRad := degToRad(lat)
+
+	eccPrimeSquared := eccSquared / (1 - eccSquared)
+
+	N := a / math.Sqrt(1-eccSquared*math.Sin(LatRad)*math.Sin(LatRad))
+	T := math.Tan(LatRad) * math.Tan(LatRad)
+	C := eccPrimeSquared * math.Cos(LatRad) * math.Cos(LatRad)
+	A := math.Cos(LatRad) * degToRad(dlon)
+
+	M := a * ((1-eccSquared/4-3*eccSquared*eccSquared/64-5*eccSquared*eccSquared*eccSquared/256)*LatRad - (3*eccSquared/8+3*eccSquared*eccSquared/32+45*eccSquared*eccSquared*eccSquared/1024)*math.Sin(2*LatRad) + (15*eccSquared*eccSquared/256+45*eccSquared*eccSquared*eccSquared/1024)*math.Sin(4*LatRad) - (35*eccSquared*eccSquared*eccSquared/3072)*math.Sin(6*LatRad))
+
+	x := k0 * N * (A + (1-T+C)*A*A*A/6.0 + (5-18*T+T*T+72*C-58*eccPrimeSquared)*A*A*A*A*A/120.0)
+
+	y := k0 * (M + N*math.Tan(LatRad)*(A*A/2+(5-T+9*C+4*C*C)*A*A*A*A/24.0+(61-58*T+T*T+600*C-330*eccPrimeSquared)*A*A*A*A*A*A/720.0))
+
+	return x, y
+}
+
+/*
+snyderInverse projects x and y (meters) to latitude and longitude difference (degrees) with the USGS/Snyder series.
+*/
+func snyderInverse(ellipsoid Ellipsoid, k0, x, y float64) (float64, float64) {
+
+	a := ellipsoid.A
+	eccSquared := ellipsoid.E2()
+	e1 := (1 - math.Sqrt(1-eccSquared)) / (1 + math.Sqrt(1-eccSquared))
+
+	eccPrimeSquared := (eccSquared) / (1 - eccSquared)
+
+	M := y / k0
+	mu := M / (a * (1 - eccSquared/4 - 3*eccSquared*eccSquared/64 - 5*eccSquared*eccSquared*eccSquared/256))
+
+	phi1Rad := mu + (3*e1/2-27*e1*e1*e1/32)*math.Sin(2*mu) + (21*e1*e1/16-55*e1*e1*e1*e1/32)*math.Sin(4*mu) + (151*e1*e1*e1/96)*math.Sin(6*mu)
+
+	N1 := a / math.Sqrt(1-eccSquared*math.Sin(phi1Rad)*math.Sin(phi1Rad))
+	T1 := math.Tan(phi1Rad) * math.Tan(phi1Rad)
+	C1 := eccPrimeSquared * math.Cos(phi1Rad) * math.Cos(phi1Rad)
+	R1 := a * (1 - eccSquared) / math.Pow(1-eccSquared*math.Sin(phi1Rad)*math.Sin(phi1Rad), 1.5)
+	D := x / (N1 * k0)
+
+	lat := phi1Rad - (N1*math.Tan(phi1Rad)/R1)*(D*D/2-(5+3*T1+10*C1-4*C1*C1-9*eccPrimeSquared)*D*D*D*D/24+(61+90*T1+298*C1+45*T1*T1-252*eccPrimeSquared-3*C1*C1)*D*D*D*D*D*D/720)
+
+	lon := (D - (1+2*T1+C1)*D*D*D/6 + (5-2*C1+28*T1-3*C1*C1+8*eccPrimeSquared+24*T1*T1)*D*D*D*D*D/120) / math.Cos(phi1Rad)
+
+	return radToDeg(lat), radToDeg(lon)
+}
@@ -0,0 +1,128 @@
+/*
+Purpose:
+- Transverse Mercator projection core
+
+Description:
+- testing
+
+Remarks:
+- https://github.com/chrisveness/geodesy/blob/master/test/utm-mgrs-tests.js
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestLL_ToUTMEngine(t *testing.T) {
+
+	var tests = []struct {
+		ll       LL       // in
+		engine   TMEngine // in
+		easting  string   // out
+		northing string   // out
+	}{
+		// positive tests
+		{LL{Lat: 0.0, Lon: 0.0}, Krueger, "166021.443", "0.000"},
+		{LL{Lat: 1.0, Lon: 1.0}, Krueger, "277438.264", "110597.973"},
+		{LL{Lat: -1.0, Lon: -1.0}, Krueger, "722561.736", "9889402.027"},
+		{LL{Lat: 60.0, Lon: 4.0}, Krueger, "221288.770", "6661953.041"},
+		{LL{Lat: 0.0, Lon: 0.0}, Snyder, "166021.443", "0.000"},
+		{LL{Lat: 1.0, Lon: 1.0}, Snyder, "277438.264", "110597.973"},
+		{LL{Lat: -1.0, Lon: -1.0}, Snyder, "722561.736", "9889402.027"},
+		{LL{Lat: 60.0, Lon: 4.0}, Snyder, "221288.770", "6661953.041"},
+	}
+
+	for _, test := range tests {
+		tm := utmProjection(EllipsoidWGS84, test.engine, getZoneNumber(test.ll.Lat, test.ll.Lon))
+		if test.ll.Lat < 0 {
+			tm.falseNorthing = 10000000.0
+		}
+		easting, northing := tm.forward(test.ll.Lat, test.ll.Lon)
+		function := fmt.Sprintf("ll = %s, ToUTMEngine(%s)", test.ll, test.engine)
+		got := fmt.Sprintf("%.3f %.3f", easting, northing)
+		want := fmt.Sprintf("%s %s", test.easting, test.northing)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestTMEngine_Compare(t *testing.T) {
+
+	var tests = []struct {
+		lat        float64 // in
+		dlon       float64 // in (distance to central meridian in degrees)
+		difference float64 // out (max. difference between Krueger and Snyder in meters)
+	}{
+		// inside of a standard UTM zone both engines agree (millimeter level)
+		{0.0, 3.0, 0.001},
+		{45.0, 3.0, 0.001},
+		{75.0, 3.0, 0.002},
+		// extended zones: the Snyder series degrades
+		{45.0, 10.0, 0.2},
+		{45.0, 20.0, 20.0},
+		{45.0, 30.0, 400.0},
+	}
+
+	for _, test := range tests {
+		krueger := transverseMercator{ellipsoid: EllipsoidWGS84, engine: Krueger, k0: 0.9996, falseEasting: 500000.0}
+		snyder := krueger
+		snyder.engine = Snyder
+
+		kx, ky := krueger.forward(test.lat, test.dlon)
+		sx, sy := snyder.forward(test.lat, test.dlon)
+		difference := math.Hypot(kx-sx, ky-sy)
+		if difference > test.difference {
+			t.Errorf("\nlat = %v, dlon = %v -> difference %.6f m > %v m\n", test.lat, test.dlon, difference, test.difference)
+		}
+
+		// the Krueger series round trip is exact to the nanometer level
+		lat, lon := krueger.inverse(kx, ky)
+		x, y := krueger.forward(lat, lon)
+		if math.Hypot(x-kx, y-ky) > 1e-6 || math.Abs(lat-test.lat) > 1e-11 || math.Abs(lon-test.dlon) > 1e-11 {
+			t.Errorf("\nlat = %v, dlon = %v -> Krueger round trip %.12f %.12f != %v %v\n", test.lat, test.dlon, lat, lon, test.lat, test.dlon)
+		}
+	}
+}
+
+func TestUTM_ToLLEngine(t *testing.T) {
+
+	var tests = []struct {
+		utm    UTM      // in
+		engine TMEngine // in
+		ll     LL       // out
+		err    error    // out
+	}{
+		// positive tests
+		{UTM{ZoneNumber: 31, ZoneLetter: 'N', Easting: 448251, Northing: 5411943}, Krueger, LL{Lat: 48.858293, Lon: 2.294488}, nil},
+		{UTM{ZoneNumber: 31, ZoneLetter: 'N', Easting: 448251, Northing: 5411943}, Snyder, LL{Lat: 48.858293, Lon: 2.294488}, nil},
+		{UTM{ZoneNumber: 56, ZoneLetter: 'H', Easting: 334873, Northing: 6252266}, Krueger, LL{Lat: -33.857001, Lon: 151.214998}, nil},
+		{UTM{ZoneNumber: 56, ZoneLetter: 'H', Easting: 334873, Northing: 6252266}, Snyder, LL{Lat: -33.857001, Lon: 151.214998}, nil},
+		// negative tests
+		{UTM{ZoneNumber: 61, ZoneLetter: 'U', Easting: 574126, Northing: 5815291}, Krueger, LL{}, fmt.Errorf("invalid zone number, zone number = 61")},
+	}
+
+	for _, test := range tests {
+		ll, err := test.utm.ToLLEngine(EllipsoidWGS84, test.engine)
+		function := fmt.Sprintf("utm = %s, ToLLEngine(%s)", test.utm, test.engine)
+		got := fmt.Sprintf("%s %v", ll, err)
+		want := fmt.Sprintf("%s %v", test.ll, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func ExampleLL_ToUTMEngine() {
+
+	ll := LL{Lat: 75.0, Lon: 8.0}
+	krueger := ll.ToUTMEngine(EllipsoidWGS84, Krueger)
+	snyder := ll.ToUTMEngine(EllipsoidWGS84, Snyder)
+	fmt.Printf("%s -> %s (%s), %s (%s)\n", ll, krueger.Format(3, RoundHalfUp), Krueger, snyder.Format(3, RoundHalfUp), Snyder)
+	// Output:
+	// 75.000000 8.000000 -> 31X 644293.433 8329692.651 (Krueger), 31X 644293.433 8329692.652 (Snyder)
+}
@@ -0,0 +1,344 @@
+/*
+Purpose:
+- UPS <-> Lon Lat, UPS <-> MGRS (polar regions)
+
+Description:
+- Universal Polar Stereographic projection for the polar regions (north of 84°N, south of 80°S)
+  and the corresponding MGRS lettering in the bands A, B (south) and Y, Z (north).
+
+Remarks:
+- Polar stereographic projection according to Snyder, Map Projections - A Working Manual (USGS 1395).
+- MGRS 100k lettering according to NGA.SIG.0012 (same tables as GeographicLib).
+
+Links:
+- https://pubs.usgs.gov/pp/1395/report.pdf
+- https://geographiclib.sourceforge.io/html/MGRS.html
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// UPS defines coordinate in Universal Polar Stereographic
+type UPS struct {
+	ZoneLetter byte // A, B (south pole) or Y, Z (north pole)
+	Easting    float64
+	Northing   float64
+}
+
+// UPS projection constants
+const (
+	upsK0            = 0.994
+	upsFalseEasting  = 2000000.0
+	upsFalseNorthing = 2000000.0
+)
+
+// upsColumnLetters defines the 100k column letters for the bands A, B, Y and Z.
+var upsColumnLetters = map[byte]string{
+	'A': "JKLPQRSTUXYZ",
+	'B': "ABCFGHJKLPQR",
+	'Y': "RSTUXYZ",
+	'Z': "ABCFGHJ",
+}
+
+// upsRowLetters defines the 100k row letters for the south (A, B) and north (Y, Z) polar regions.
+var upsRowLetters = map[bool]string{
+	false: "ABCDEFGHJKLMNPQRSTUVWXYZ",
+	true:  "ABCDEFGHJKLMNP",
+}
+
+// upsMinIndex defines the 100k index of the first column/row for the south and north polar regions.
+var upsMinIndex = map[bool]int{
+	false: 8,
+	true:  13,
+}
+
+/*
+String returns stringified UPS object (rounded to full meters).
+*/
+func (ups UPS) String() string {
+
+	return ups.Format(0, RoundHalfUp)
+}
+
+/*
+Format returns stringified UPS object with the given number of decimals.
+decimals holds the number of decimals for easting and northing (0 = meters, 2 = centimeters, ...).
+rounding holds the rounding mode (RoundHalfUp or Truncate).
+*/
+func (ups UPS) Format(decimals int, rounding Rounding) string {
+
+	if decimals < 0 {
+		decimals = 0
+	}
+	unit := math.Pow(10, -float64(decimals))
+
+	return fmt.Sprintf("%c %.*f %.*f", ups.ZoneLetter,
+		decimals, roundValue(ups.Easting, unit, rounding),
+		decimals, roundValue(ups.Northing, unit, rounding))
+}
+
+/*
+isNorth reports whether the UPS coordinate belongs to the north polar region.
+*/
+func (ups UPS) isNorth() bool {
+
+	return ups.ZoneLetter == 'Y' || ups.ZoneLetter == 'Z'
+}
+
+/*
+isPolarLetter reports whether the letter is one of the polar MGRS band letters A, B, Y or Z.
+*/
+func isPolarLetter(letter byte) bool {
+
+	return letter == 'A' || letter == 'B' || letter == 'Y' || letter == 'Z'
+}
+
+/*
+isPolarLat reports whether the latitude is in a polar region not covered by UTM (north of 84°N, south of 80°S).
+*/
+func isPolarLat(lat float64) bool {
+
+	return lat > 84 || lat < -80
+}
+
+/*
+upsFactor returns the constant part of the polar stereographic radius (2 * a * k0 / sqrt((1+e)^(1+e) * (1-e)^(1-e))).
+*/
+func upsFactor(ellipsoid Ellipsoid) float64 {
+
+	e := math.Sqrt(ellipsoid.E2())
+	return 2.0 * ellipsoid.A * upsK0 / math.Sqrt(math.Pow(1+e, 1+e)*math.Pow(1-e, 1-e))
+}
+
+/*
+ToUPS converts Lon Lat to UPS (WGS84 ellipsoid).
+*/
+func (ll LL) ToUPS() UPS {
+
+	return ll.ToUPSEllipsoid(EllipsoidWGS84)
+}
+
+/*
+ToUPSEllipsoid converts Lon Lat to UPS based on the given ellipsoid.
+The hemisphere is selected by the sign of the latitude.
+ellipsoid holds the reference ellipsoid of the Lon Lat coordinate.
+*/
+func (ll LL) ToUPSEllipsoid(ellipsoid Ellipsoid) UPS {
+
+	north := ll.Lat >= 0
+	e := math.Sqrt(ellipsoid.E2())
+	latRad := degToRad(math.Abs(ll.Lat))
+	// exact values on the meridians 0°, ±90° and ±180° (no sin(±π) noise at the A/B and Y/Z boundary)
+	sinLon, cosLon := sincosd(ll.Lon)
+
+	t := math.Tan(math.Pi/4-latRad/2) / math.Pow((1-e*math.Sin(latRad))/(1+e*math.Sin(latRad)), e/2)
+	rho := upsFactor(ellipsoid) * t
+
+	ups := UPS{}
+	ups.Easting = upsFalseEasting + rho*sinLon
+	if north {
+		ups.Northing = upsFalseNorthing - rho*cosLon
+	} else {
+		ups.Northing = upsFalseNorthing + rho*cosLon
+	}
+
+	switch {
+	case north && ups.Easting < upsFalseEasting:
+		ups.ZoneLetter = 'Y'
+	case north:
+		ups.ZoneLetter = 'Z'
+	case ups.Easting < upsFalseEasting:
+		ups.ZoneLetter = 'A'
+	default:
+		ups.ZoneLetter = 'B'
+	}
+
+	return ups
+}
+
+/*
+ToLL converts UPS to Lon Lat (WGS84 ellipsoid).
+*/
+func (ups UPS) ToLL() (LL, error) {
+
+	return ups.ToLLEllipsoid(EllipsoidWGS84)
+}
+
+/*
+ToLLEllipsoid converts UPS to Lon Lat based on the given ellipsoid.
+ellipsoid holds the reference ellipsoid of the UPS coordinate.
+*/
+func (ups UPS) ToLLEllipsoid(ellipsoid Ellipsoid) (LL, error) {
+
+	if !isPolarLetter(ups.ZoneLetter) {
+		return LL{}, fmt.Errorf("invalid ups zone letter, zone letter = %c", ups.ZoneLetter)
+	}
+
+	north := ups.isNorth()
+	e := math.Sqrt(ellipsoid.E2())
+	dx := ups.Easting - upsFalseEasting
+	dy := ups.Northing - upsFalseNorthing
+
+	rho := math.Hypot(dx, dy)
+	t := rho / upsFactor(ellipsoid)
+
+	// iterate the latitude, starting with the conformal latitude
+	latRad := math.Pi/2 - 2*math.Atan(t)
+	for i := 0; i < 20; i++ {
+		esin := e * math.Sin(latRad)
+		next := math.Pi/2 - 2*math.Atan(t*math.Pow((1-esin)/(1+esin), e/2))
+		if math.Abs(next-latRad) < 1e-14 {
+			latRad = next
+			break
+		}
+		latRad = next
+	}
+
+	ll := LL{}
+	if rho == 0 {
+		// longitude is undefined at the pole
+		ll.Lat = 90
+		if !north {
+			ll.Lat = -90
+		}
+	} else if north {
+		ll.Lat = radToDeg(latRad)
+		ll.Lon = radToDeg(math.Atan2(dx, -dy))
+	} else {
+		ll.Lat = -radToDeg(latRad)
+		ll.Lon = radToDeg(math.Atan2(dx, dy))
+	}
+
+	return ll, nil
+}
+
+/*
+ToMGRS converts UPS to polar MGRS/UTMREF (no zone number, band letter A, B, Y or Z).
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+Easting and northing are truncated to the accuracy (MGRS convention).
+*/
+func (ups UPS) ToMGRS(accuracy int) (MGRS, error) {
+
+	return ups.ToMGRSRounding(accuracy, Truncate)
+}
+
+/*
+ToMGRSRounding converts UPS to polar MGRS/UTMREF with the given rounding mode.
+accuracy holds the wanted accuracy in meters. Possible values are 1, 10, 100, 1000 or 10000 meters.
+rounding holds the rounding mode (Truncate or RoundHalfUp).
+*/
+func (ups UPS) ToMGRSRounding(accuracy int, rounding Rounding) (MGRS, error) {
+
+	if !isPolarLetter(ups.ZoneLetter) {
+		return "", fmt.Errorf("invalid ups zone letter, zone letter = %c", ups.ZoneLetter)
+	}
+
+	digits := accuracyToDigits(accuracy)
+	unit := math.Pow(10, float64(5-digits))
+	easting := roundValue(ups.Easting, unit, rounding)
+	northing := roundValue(ups.Northing, unit, rounding)
+
+	// the zone letter follows the rounded easting (A/Y west, B/Z east of the false easting)
+	zoneLetter := ups.ZoneLetter
+	switch {
+	case zoneLetter == 'A' && easting >= upsFalseEasting:
+		zoneLetter = 'B'
+	case zoneLetter == 'B' && easting < upsFalseEasting:
+		zoneLetter = 'A'
+	case zoneLetter == 'Y' && easting >= upsFalseEasting:
+		zoneLetter = 'Z'
+	case zoneLetter == 'Z' && easting < upsFalseEasting:
+		zoneLetter = 'Y'
+	}
+
+	north := ups.isNorth()
+	columns := upsColumnLetters[zoneLetter]
+	rows := upsRowLetters[north]
+
+	column := int(math.Floor(easting/100000)) - upsMinIndex[north]
+	if zoneLetter == 'B' || zoneLetter == 'Z' {
+		column = int(math.Floor(easting/100000)) - int(upsFalseEasting/100000)
+	}
+	row := int(math.Floor(northing/100000)) - upsMinIndex[north]
+
+	if column < 0 || column >= len(columns) || row < 0 || row >= len(rows) {
+		return "", fmt.Errorf("ups coordinate outside of mgrs polar grid, ups = %s", ups)
+	}
+
+	mgrs := fmt.Sprintf("%c%c%c%s%s",
+		zoneLetter,
+		columns[column],
+		rows[row],
+		mgrsDigits(easting, digits),
+		mgrsDigits(northing, digits))
+
+	return MGRS(mgrs), nil
+}
+
+/*
+isPolar reports whether the MGRS/UTMREF string denotes a polar (UPS) position (no zone number, band A, B, Y or Z).
+*/
+func (mgrs MGRS) isPolar() bool {
+
+	s := strings.TrimSpace(string(mgrs))
+	if s == "" {
+		return false
+	}
+
+	return isPolarLetter(strings.ToUpper(s[:1])[0])
+}
+
+/*
+ToUPS converts polar MGRS/UTMREF to UPS.
+*/
+func (mgrs MGRS) ToUPS() (UPS, int, error) {
+
+	if mgrs == "" {
+		return UPS{}, 0, fmt.Errorf("invalid empty mgrs string")
+	}
+
+	c, err := ParseMGRS(string(mgrs))
+	if err != nil {
+		return UPS{}, 0, err
+	}
+
+	if c.ZoneNumber != 0 {
+		return UPS{}, 0, fmt.Errorf("mgrs not polar (use mgrs.ToUTM()), mgrs = %s", mgrs)
+	}
+
+	zoneLetter := c.ZoneLetter
+	north := zoneLetter == 'Y' || zoneLetter == 'Z'
+
+	column := strings.IndexByte(upsColumnLetters[zoneLetter], c.ID100k[0])
+	row := strings.IndexByte(upsRowLetters[north], c.ID100k[1])
+
+	if zoneLetter == 'B' || zoneLetter == 'Z' {
+		column += int(upsFalseEasting / 100000)
+	} else {
+		column += upsMinIndex[north]
+	}
+	row += upsMinIndex[north]
+
+	sepEasting, sepNorthing, accuracy := c.values()
+
+	ups := UPS{}
+	ups.ZoneLetter = zoneLetter
+	ups.Easting = float64(column)*100000 + sepEasting
+	ups.Northing = float64(row)*100000 + sepNorthing
+
+	// the polar grid is square, reject squares (cells) outside of the polar cap
+	size := float64(accuracy)
+	if accuracy == 0 {
+		size = 100000
+	}
+	if err := checkInPolarCap(ups, size); err != nil {
+		return UPS{}, 0, fmt.Errorf("%w, mgrs = %s", err, mgrs)
+	}
+
+	return ups, accuracy, nil
+}
@@ -0,0 +1,179 @@
+/*
+Purpose:
+- UPS <-> Lon Lat, UPS <-> MGRS (polar regions)
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"testing"
+)
+
+func TestLL_ToUPS(t *testing.T) {
+
+	var tests = []struct {
+		ll  LL  // in
+		ups UPS // out
+	}{
+		// positive tests
+		{LL{Lat: 90.0, Lon: 0.0}, UPS{ZoneLetter: 'Z', Easting: 2000000, Northing: 2000000}},
+		{LL{Lat: -90.0, Lon: 0.0}, UPS{ZoneLetter: 'B', Easting: 2000000, Northing: 2000000}},
+		{LL{Lat: 85.0, Lon: -30.0}, UPS{ZoneLetter: 'Y', Easting: 1722271, Northing: 1518960}},
+		{LL{Lat: 84.5, Lon: 170.0}, UPS{ZoneLetter: 'Z', Easting: 2106113, Northing: 2601798}},
+		{LL{Lat: -85.0, Lon: 130.0}, UPS{ZoneLetter: 'B', Easting: 2425505, Northing: 1642959}},
+		{LL{Lat: -88.95, Lon: -7.53}, UPS{ZoneLetter: 'A', Easting: 1984723, Northing: 2115573}},
+	}
+
+	for _, test := range tests {
+		ups := test.ll.ToUPS()
+		function := fmt.Sprintf("ll = %s, ToUPS()", test.ll)
+		got := ups.String()
+		want := test.ups.String()
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestUPS_ToLL(t *testing.T) {
+
+	var tests = []struct {
+		ups UPS   // in
+		ll  LL    // out
+		err error // out
+	}{
+		// positive tests
+		{UPS{ZoneLetter: 'Z', Easting: 2000000, Northing: 2000000}, LL{Lat: 90.0, Lon: 0.0}, nil},
+		{UPS{ZoneLetter: 'A', Easting: 2000000, Northing: 2000000}, LL{Lat: -90.0, Lon: 0.0}, nil},
+		{UPS{ZoneLetter: 'Y', Easting: 1722271.304, Northing: 1518959.788}, LL{Lat: 85.0, Lon: -30.0}, nil},
+		{UPS{ZoneLetter: 'B', Easting: 2425505.048, Northing: 1642958.871}, LL{Lat: -85.0, Lon: 130.0}, nil},
+		// negative tests
+		{UPS{ZoneLetter: 'U', Easting: 2000000, Northing: 2000000}, LL{}, fmt.Errorf("invalid ups zone letter, zone letter = U")},
+	}
+
+	for _, test := range tests {
+		ll, err := test.ups.ToLL()
+		function := fmt.Sprintf("ups = %s, ToLL()", test.ups)
+		got := fmt.Sprintf("%s %v", ll, err)
+		want := fmt.Sprintf("%s %v", test.ll, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMGRS_ToUPS(t *testing.T) {
+
+	var tests = []struct {
+		mgrs     MGRS  // in
+		ups      UPS   // out
+		accuracy int   // out
+		err      error // out
+	}{
+		// positive tests
+		{"ZAH0000000000", UPS{ZoneLetter: 'Z', Easting: 2000000, Northing: 2000000}, 1, nil},
+		{"BAN0000000000", UPS{ZoneLetter: 'B', Easting: 2000000, Northing: 2000000}, 1, nil},
+		{"YXC2227118959", UPS{ZoneLetter: 'Y', Easting: 1722271, Northing: 1518959}, 1, nil},
+		{"ZBP0611301798", UPS{ZoneLetter: 'Z', Easting: 2106113, Northing: 2601798}, 1, nil},
+		{"BGJ255429", UPS{ZoneLetter: 'B', Easting: 2425500, Northing: 1642900}, 100, nil},
+		{"AZP84721557", UPS{ZoneLetter: 'A', Easting: 1984720, Northing: 2115570}, 10, nil},
+		// negative tests
+		{"ZQH0000000000", UPS{}, 0, fmt.Errorf("invalid 100k column letter 'Q' for zone Z at position 2, mgrs = ZQH0000000000")},
+		{"YXZ2227118960", UPS{}, 0, fmt.Errorf("invalid 100k row letter 'Z' for zone Y at position 3, mgrs = YXZ2227118960")},
+		{"ZAH000000000", UPS{}, 0, fmt.Errorf("uneven number of digits (9) at position 12, mgrs = ZAH000000000")},
+		{"AKZ", UPS{}, 0, fmt.Errorf("square not in zone, polar cap = A (lat -90..-80), ups = A 900000 3100000, mgrs = AKZ")},
+		{"AKZ12345678", UPS{}, 0, fmt.Errorf("square not in zone, polar cap = A (lat -90..-80), ups = A 912340 3156780, mgrs = AKZ12345678")},
+	}
+
+	for _, test := range tests {
+		ups, accuracy, err := test.mgrs.ToUPS()
+		function := fmt.Sprintf("mgrs = %s, ToUPS()", test.mgrs)
+		got := fmt.Sprintf("%s %d %v", ups, accuracy, err)
+		want := fmt.Sprintf("%s %d %v", test.ups, test.accuracy, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestMGRS_ToLL_Polar(t *testing.T) {
+
+	var tests = []struct {
+		mgrs     MGRS  // in
+		ll       LL    // out
+		accuracy int   // out
+		err      error // out
+	}{
+		// positive tests
+		{"ZAH0000000000", LL{Lat: 90.0, Lon: 0.0}, 1, nil},
+		{"BAN0000000000", LL{Lat: -90.0, Lon: 0.0}, 1, nil},
+		{"zaf1527784427", LL{Lat: 88.949998, Lon: 7.529980}, 1, nil},
+		// negative tests
+		{"ZQH00", LL{}, 0, fmt.Errorf("error <invalid 100k column letter 'Q' for zone Z at position 2, mgrs = ZQH00> at mgrs.ToUPS()")},
+		{"AKZ", LL{}, 0, fmt.Errorf("error <square not in zone, polar cap = A (lat -90..-80), ups = A 900000 3100000, mgrs = AKZ> at mgrs.ToUPS()")},
+	}
+
+	for _, test := range tests {
+		ll, accuracy, err := test.mgrs.ToLL()
+		function := fmt.Sprintf("mgrs = %s, mgrs.ToLL()", test.mgrs)
+		got := fmt.Sprintf("%s %d %v", ll, accuracy, err)
+		want := fmt.Sprintf("%s %d %v", test.ll, test.accuracy, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestLL_ToMGRS_Antimeridian(t *testing.T) {
+
+	var tests = []struct {
+		ll   LL     // in
+		mgrs string // out
+	}{
+		// positive tests (lon ±180 is the boundary of the zones A/B and Y/Z)
+		{LL{Lat: -80.003903, Lon: -180}, "BAA0000087485"},
+		{LL{Lat: -80.003903, Lon: 180}, "BAA0000087485"},
+		{LL{Lat: -85, Lon: -180}, "BAG0000044542"},
+		{LL{Lat: 85, Lon: 180}, "ZAN0000055457"},
+		{LL{Lat: -85, Lon: -179.9999999999}, "AZG9999944542"},
+	}
+
+	for _, test := range tests {
+		mgrs, err := test.ll.ToMGRS(1)
+		function := fmt.Sprintf("ll = %s, ToMGRS(1)", test.ll)
+		got := fmt.Sprintf("%s %v", mgrs, err)
+		want := fmt.Sprintf("%s %v", test.mgrs, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+		if _, _, err := mgrs.ToLL(); err != nil {
+			t.Errorf("\n%s -> error <%v> at mgrs.ToLL()\n", function, err)
+		}
+	}
+
+	// rounding up to the false easting switches the zone letter from A to B
+	mgrs, err := LL{Lat: -85, Lon: -179.9999999999}.ToUPS().ToMGRSRounding(1, RoundHalfUp)
+	got := fmt.Sprintf("%s %v", mgrs, err)
+	want := "BAG0000044543 <nil>"
+	if got != want {
+		t.Errorf("\nUPS.ToMGRSRounding(1, RoundHalfUp) -> %s != %s\n", got, want)
+	}
+}
+
+func ExampleLL_ToUPS() {
+
+	ll := LL{Lat: 85.0, Lon: -30.0}
+	ups := ll.ToUPS()
+	mgrs, err := ups.ToMGRS(1)
+	if err != nil {
+		log.Fatalf("error <%v> at ups.ToMGRS()", err)
+	}
+	fmt.Printf("%s -> %s -> %s\n", ll, ups, mgrs)
+	// Output:
+	// 85.000000 -30.000000 -> Y 1722271 1518960 -> YXC2227118959
+}
@@ -0,0 +1,494 @@
+/*
+Purpose:
+- Web Mercator (EPSG:3857) and slippy map tiles (z/x/y, quadkey) <-> Lon Lat, MGRS
+
+Description:
+- Web Mercator projects WGS84 Lon Lat with the spherical Mercator formulas on a sphere with the
+  WGS84 semi-major axis (X = easting, Y = northing in meters).
+- Tiles follow the slippy map scheme: zoom level z (0-30), x from 180°W eastwards, y from the
+  northern edge (85.0511°N) southwards. Quadkeys encode the same tile as base-4 string (one digit per zoom level).
+- For an MGRS overlay the grid zones and 100k squares overlapping a tile are listed.
+
+Remarks:
+- Latitudes beyond ±85.0511° are outside of the tile pyramid.
+- Web Mercator is not conformal on the ellipsoid, use it for display purposes only.
+- 100k squares are determined in the UTM (UPS) plane of each grid zone, the tile boundary is densified
+  (curved parallels) before testing the squares for overlap.
+
+Links:
+- https://epsg.io/3857
+- https://wiki.openstreetmap.org/wiki/Slippy_map_tilenames
+- https://learn.microsoft.com/en-us/bingmaps/articles/bing-maps-tile-system
+*/
+
+package coco
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// WebMercator defines coordinate in Web Mercator (EPSG:3857)
+type WebMercator struct {
+	X float64 // easting
+	Y float64 // northing
+}
+
+// Tile defines a slippy map tile (zoom level, column and row)
+type Tile struct {
+	Z int // zoom level (0-30)
+	X int // column (0 = 180°W)
+	Y int // row (0 = 85.0511°N)
+}
+
+// Quadkey defines a tile in quadkey notation (e.g. "120210")
+type Quadkey string
+
+// Web Mercator parameters
+const (
+	webMercatorRadius = 6378137.0                   // WGS84 semi-major axis
+	webMercatorMaxLat = 85.05112877980659           // northern edge of the tile pyramid, atan(sinh(pi))
+	webMercatorMaxX   = math.Pi * webMercatorRadius // half of the equator length
+)
+
+// tile parameters
+const (
+	tileMaxZoom   = 30     // maximum zoom level
+	tileDensify   = 16     // boundary points per tile edge (MGRS overlay)
+	quadkeyDigits = "0123" // quadkey digits (bit 0 = x, bit 1 = y)
+)
+
+/*
+String returns stringified WebMercator object (X and Y, precision centimeters).
+*/
+func (wm WebMercator) String() string {
+
+	return fmt.Sprintf("%.2f %.2f", wm.X, wm.Y)
+}
+
+/*
+String returns stringified Tile object (z/x/y).
+*/
+func (tile Tile) String() string {
+
+	return fmt.Sprintf("%d/%d/%d", tile.Z, tile.X, tile.Y)
+}
+
+/*
+ToWebMercator converts Lon Lat (WGS84) to Web Mercator.
+*/
+func (ll LL) ToWebMercator() (WebMercator, error) {
+
+	if !(ll.Lon >= -180 && ll.Lon <= 180) {
+		return WebMercator{}, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if !(ll.Lat > -90 && ll.Lat < 90) {
+		return WebMercator{}, fmt.Errorf("invalid latitude (poles not projectable), lat = %v", ll.Lat)
+	}
+
+	wm := WebMercator{}
+	wm.X = webMercatorRadius * degToRad(ll.Lon)
+	wm.Y = webMercatorRadius * math.Log(math.Tan(math.Pi/4+degToRad(ll.Lat)/2))
+
+	return wm, nil
+}
+
+/*
+ToLL converts Web Mercator to Lon Lat (WGS84).
+*/
+func (wm WebMercator) ToLL() (LL, error) {
+
+	if math.IsNaN(wm.X) || math.IsNaN(wm.Y) || math.IsInf(wm.Y, 0) || math.Abs(wm.X) > webMercatorMaxX+1e-6 {
+		return LL{}, fmt.Errorf("invalid web mercator coordinate, wm = %s", wm)
+	}
+
+	ll := LL{}
+	ll.Lon = radToDeg(wm.X / webMercatorRadius)
+	ll.Lat = radToDeg(2*math.Atan(math.Exp(wm.Y/webMercatorRadius)) - math.Pi/2)
+
+	return ll, nil
+}
+
+/*
+ToTile returns the slippy map tile containing the position.
+zoom holds the zoom level (0-30).
+*/
+func (ll LL) ToTile(zoom int) (Tile, error) {
+
+	if zoom < 0 || zoom > tileMaxZoom {
+		return Tile{}, fmt.Errorf("invalid zoom level (0-%d), zoom = %d", tileMaxZoom, zoom)
+	}
+	if !(ll.Lon >= -180 && ll.Lon <= 180) {
+		return Tile{}, fmt.Errorf("invalid longitude, lon = %v", ll.Lon)
+	}
+	if !(ll.Lat >= -webMercatorMaxLat && ll.Lat <= webMercatorMaxLat) {
+		return Tile{}, fmt.Errorf("latitude outside of tile pyramid (±%.4f), lat = %v", webMercatorMaxLat, ll.Lat)
+	}
+
+	n := math.Exp2(float64(zoom))
+	latRad := degToRad(ll.Lat)
+	x := (normalizeLon(ll.Lon) + 180) / 360 * n
+	y := (1 - math.Log(math.Tan(latRad)+1/math.Cos(latRad))/math.Pi) / 2 * n
+
+	tile := Tile{Z: zoom, X: int(math.Floor(x)), Y: int(math.Floor(y))}
+	last := int(n) - 1
+	if tile.X > last {
+		tile.X = last
+	}
+	if tile.Y < 0 {
+		tile.Y = 0
+	}
+	if tile.Y > last {
+		tile.Y = last // southern edge of the pyramid
+	}
+
+	return tile, nil
+}
+
+/*
+Validate checks the tile (zoom level 0-30, column and row within the zoom level).
+*/
+func (tile Tile) Validate() error {
+
+	if tile.Z < 0 || tile.Z > tileMaxZoom {
+		return fmt.Errorf("invalid zoom level (0-%d), zoom = %d", tileMaxZoom, tile.Z)
+	}
+	n := 1 << uint(tile.Z)
+	if tile.X < 0 || tile.X >= n || tile.Y < 0 || tile.Y >= n {
+		return fmt.Errorf("tile outside of tile pyramid, tile = %s", tile)
+	}
+
+	return nil
+}
+
+/*
+Bounds returns the bounding box of the tile.
+*/
+func (tile Tile) Bounds() (BoundingBox, error) {
+
+	if err := tile.Validate(); err != nil {
+		return BoundingBox{}, err
+	}
+
+	n := math.Exp2(float64(tile.Z))
+	tileLat := func(y int) float64 {
+		return radToDeg(math.Atan(math.Sinh(math.Pi * (1 - 2*float64(y)/n))))
+	}
+
+	bbox := BoundingBox{}
+	bbox.West = float64(tile.X)/n*360 - 180
+	bbox.East = float64(tile.X+1)/n*360 - 180
+	bbox.North = tileLat(tile.Y)
+	bbox.South = tileLat(tile.Y + 1)
+
+	return bbox, nil
+}
+
+/*
+Quadkey returns the quadkey of the tile (one digit per zoom level, empty for zoom level 0).
+*/
+func (tile Tile) Quadkey() (Quadkey, error) {
+
+	if err := tile.Validate(); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for z := tile.Z; z > 0; z-- {
+		mask := 1 << uint(z-1)
+		digit := 0
+		if tile.X&mask != 0 {
+			digit++
+		}
+		if tile.Y&mask != 0 {
+			digit += 2
+		}
+		sb.WriteByte(quadkeyDigits[digit])
+	}
+
+	return Quadkey(sb.String()), nil
+}
+
+/*
+ToTile converts the quadkey to a slippy map tile.
+*/
+func (qk Quadkey) ToTile() (Tile, error) {
+
+	if len(qk) > tileMaxZoom {
+		return Tile{}, fmt.Errorf("invalid quadkey length (0-%d), quadkey = %s", tileMaxZoom, qk)
+	}
+
+	tile := Tile{Z: len(qk)}
+	for i := 0; i < len(qk); i++ {
+		digit := strings.IndexByte(quadkeyDigits, qk[i])
+		if digit < 0 {
+			return Tile{}, fmt.Errorf("invalid quadkey digit %q at position %d, quadkey = %s", qk[i], i+1, qk)
+		}
+		tile.X = tile.X<<1 | digit&1
+		tile.Y = tile.Y<<1 | digit>>1
+	}
+
+	return tile, nil
+}
+
+/*
+MGRSGridZones returns the MGRS grid zone designations (e.g. "32U", polar "Z") overlapping the tile.
+The zones are sorted from south to north and from west to east.
+*/
+func (tile Tile) MGRSGridZones() ([]string, error) {
+
+	bbox, err := tile.Bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	zones := []string{}
+	for _, area := range mgrsZoneAreas(bbox) {
+		zones = append(zones, area.gzd)
+	}
+
+	return zones, nil
+}
+
+/*
+MGRS100kSquares returns the MGRS 100k squares (e.g. "32ULC", polar "ZGC") overlapping the tile.
+The squares are sorted by grid zone (see MGRSGridZones), then by easting and northing.
+*/
+func (tile Tile) MGRS100kSquares() ([]MGRS, error) {
+
+	bbox, err := tile.Bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	squares := []MGRS{}
+	for _, area := range mgrsZoneAreas(bbox) {
+		// densified boundary of the tile part inside the grid zone, projected to the zone plane
+		polygon := [][2]float64{}
+		minE, minN := math.Inf(1), math.Inf(1)
+		maxE, maxN := math.Inf(-1), math.Inf(-1)
+		for _, ll := range densifyBounds(area.bbox, tileDensify) {
+			e, n := area.project(ll)
+			polygon = append(polygon, [2]float64{e, n})
+			minE, maxE = math.Min(minE, e), math.Max(maxE, e)
+			minN, maxN = math.Min(minN, n), math.Max(maxN, n)
+		}
+
+		for col := int(math.Floor(minE / 100000)); col <= int(math.Floor(maxE/100000)); col++ {
+			for row := int(math.Floor(minN / 100000)); row <= int(math.Floor(maxN/100000)); row++ {
+				e, n := float64(col)*100000, float64(row)*100000
+				if !polygonOverlapsRect(polygon, e, n, e+100000, n+100000) {
+					continue
+				}
+				square, err := area.square(e, n)
+				if err != nil {
+					continue // outside of the mgrs grid
+				}
+				squares = append(squares, square)
+			}
+		}
+	}
+
+	return squares, nil
+}
+
+// mgrsZoneArea defines the part of a bounding box inside a MGRS grid zone and its projection
+type mgrsZoneArea struct {
+	gzd     string                               // grid zone designation
+	bbox    BoundingBox                          // bounding box clipped to the grid zone
+	project func(LL) (float64, float64)          // Lon Lat to easting and northing
+	square  func(float64, float64) (MGRS, error) // 100k square at south-west corner easting and northing
+}
+
+/*
+mgrsZoneAreas returns the MGRS grid zones overlapping the bounding box (UTM zones including the Norway and
+Svalbard exceptions, UPS zones A, B, Y and Z), sorted from south to north and from west to east.
+*/
+func mgrsZoneAreas(bbox BoundingBox) []mgrsZoneArea {
+
+	type zone struct {
+		number int
+		letter byte
+	}
+	candidates := []zone{}
+	for _, letter := range []byte("AB" + bandLetters + "YZ") {
+		if isPolarLetter(letter) {
+			candidates = append(candidates, zone{0, letter})
+			continue
+		}
+		for number := 1; number <= 60; number++ {
+			candidates = append(candidates, zone{number, letter})
+		}
+	}
+
+	areas := []mgrsZoneArea{}
+	for _, candidate := range candidates {
+		var south, north, west, east float64
+		if candidate.number == 0 {
+			south, north, west, east = -90, -80, -180, 0
+			if candidate.letter == 'Y' || candidate.letter == 'Z' {
+				south, north = 84, 90
+			}
+			if candidate.letter == 'B' || candidate.letter == 'Z' {
+				west, east = 0, 180
+			}
+		} else {
+			var err error
+			south, north, west, east, err = gzdBounds(candidate.number, candidate.letter)
+			if err != nil {
+				continue // Svalbard exception
+			}
+		}
+
+		// overlap with positive area only (tiles touching a zone boundary)
+		clipped := BoundingBox{
+			South: math.Max(bbox.South, south), West: math.Max(bbox.West, west),
+			North: math.Min(bbox.North, north), East: math.Min(bbox.East, east),
+		}
+		if clipped.South >= clipped.North || clipped.West >= clipped.East {
+			continue
+		}
+
+		area := mgrsZoneArea{bbox: clipped}
+		number, letter := candidate.number, candidate.letter
+		if number == 0 {
+			area.gzd = string(letter)
+			area.project = func(ll LL) (float64, float64) {
+				ups := ll.ToUPS()
+				return ups.Easting, ups.Northing
+			}
+			area.square = func(e, n float64) (MGRS, error) {
+				mgrs, err := UPS{ZoneLetter: letter, Easting: e, Northing: n}.ToMGRS(10000)
+				if err != nil {
+					return "", err
+				}
+				return mgrs[:len(mgrs)-2], nil
+			}
+		} else {
+			area.gzd = fmt.Sprintf("%d%c", number, letter)
+			tm := utmProjection(EllipsoidWGS84, Krueger, number)
+			if letter < 'N' {
+				tm.falseNorthing = 10000000.0
+			}
+			area.project = func(ll LL) (float64, float64) {
+				return tm.forward(ll.Lat, ll.Lon)
+			}
+			area.square = func(e, n float64) (MGRS, error) {
+				mgrs := UTM{ZoneNumber: number, ZoneLetter: letter, Easting: e, Northing: n}.ToMGRS(10000)
+				return mgrs[:len(mgrs)-2], nil
+			}
+		}
+		areas = append(areas, area)
+	}
+
+	// south to north, west to east
+	sort.SliceStable(areas, func(i, j int) bool {
+		if areas[i].bbox.South != areas[j].bbox.South {
+			return areas[i].bbox.South < areas[j].bbox.South
+		}
+		return areas[i].bbox.West < areas[j].bbox.West
+	})
+
+	return areas
+}
+
+/*
+densifyBounds returns the boundary of the bounding box as ring of Lon Lat points (counterclockwise,
+starting south-west, not closed).
+points holds the number of points per edge.
+*/
+func densifyBounds(bbox BoundingBox, points int) []LL {
+
+	ring := []LL{}
+	for i := 0; i < points; i++ {
+		f := float64(i) / float64(points)
+		ring = append(ring, LL{Lat: bbox.South, Lon: bbox.West + f*(bbox.East-bbox.West)})
+	}
+	for i := 0; i < points; i++ {
+		f := float64(i) / float64(points)
+		ring = append(ring, LL{Lat: bbox.South + f*(bbox.North-bbox.South), Lon: bbox.East})
+	}
+	for i := 0; i < points; i++ {
+		f := float64(i) / float64(points)
+		ring = append(ring, LL{Lat: bbox.North, Lon: bbox.East - f*(bbox.East-bbox.West)})
+	}
+	for i := 0; i < points; i++ {
+		f := float64(i) / float64(points)
+		ring = append(ring, LL{Lat: bbox.North - f*(bbox.North-bbox.South), Lon: bbox.West})
+	}
+
+	return ring
+}
+
+/*
+polygonOverlapsRect reports whether the polygon (ring, not closed) and the rectangle share a positive area.
+Touching edges do not count as overlap.
+*/
+func polygonOverlapsRect(polygon [][2]float64, minX, minY, maxX, maxY float64) bool {
+
+	// polygon vertex inside the rectangle
+	for _, p := range polygon {
+		if p[0] > minX && p[0] < maxX && p[1] > minY && p[1] < maxY {
+			return true
+		}
+	}
+
+	// rectangle center or corner inside the polygon
+	corners := [][2]float64{{minX, minY}, {maxX, minY}, {maxX, maxY}, {minX, maxY}}
+	center := [2]float64{(minX + maxX) / 2, (minY + maxY) / 2}
+	if pointInPolygon(center, polygon) {
+		return true
+	}
+	for _, c := range corners {
+		if pointInPolygon(c, polygon) {
+			return true
+		}
+	}
+
+	// proper crossing of polygon and rectangle edges
+	for i := range polygon {
+		a, b := polygon[i], polygon[(i+1)%len(polygon)]
+		for j := range corners {
+			if segmentsCross(a, b, corners[j], corners[(j+1)%len(corners)]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+/*
+pointInPolygon reports whether the point lies inside the polygon (ring, not closed, even-odd rule).
+*/
+func pointInPolygon(p [2]float64, polygon [][2]float64) bool {
+
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a[1] > p[1]) != (b[1] > p[1]) && p[0] < (b[0]-a[0])*(p[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+/*
+segmentsCross reports whether the segments a1-a2 and b1-b2 cross each other (end points excluded).
+*/
+func segmentsCross(a1, a2, b1, b2 [2]float64) bool {
+
+	cross := func(o, p, q [2]float64) float64 {
+		return (p[0]-o[0])*(q[1]-o[1]) - (p[1]-o[1])*(q[0]-o[0])
+	}
+
+	d1 := cross(b1, b2, a1)
+	d2 := cross(b1, b2, a2)
+	d3 := cross(a1, a2, b1)
+	d4 := cross(a1, a2, b2)
+
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
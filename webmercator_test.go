@@ -0,0 +1,241 @@
+/*
+Purpose:
+- Web Mercator (EPSG:3857) and slippy map tiles (z/x/y, quadkey) <-> Lon Lat, MGRS
+
+Description:
+- testing
+*/
+
+package coco
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLL_ToWebMercator(t *testing.T) {
+
+	var tests = []struct {
+		ll  LL          // in
+		wm  WebMercator // out
+		err error       // out
+	}{
+		// positive tests
+		{LL{Lat: 52.52, Lon: 13.405}, WebMercator{X: 1492237.77, Y: 6894699.80}, nil},
+		{LL{Lat: -33.8675, Lon: 151.207}, WebMercator{X: 16832286.24, Y: -4011024.36}, nil},
+		{LL{Lat: 0, Lon: 0}, WebMercator{X: 0, Y: 0}, nil},
+		{LL{Lat: 85.05112877980659, Lon: 180}, WebMercator{X: 20037508.34, Y: 20037508.34}, nil},
+		// negative tests
+		{LL{Lat: 90, Lon: 0}, WebMercator{}, fmt.Errorf("invalid latitude (poles not projectable), lat = 90")},
+		{LL{Lat: 0, Lon: -181}, WebMercator{}, fmt.Errorf("invalid longitude, lon = -181")},
+		{LL{Lat: 50, Lon: math.NaN()}, WebMercator{}, fmt.Errorf("invalid longitude, lon = NaN")},
+		{LL{Lat: math.NaN(), Lon: 10}, WebMercator{}, fmt.Errorf("invalid latitude (poles not projectable), lat = NaN")},
+	}
+
+	for _, test := range tests {
+		wm, err := test.ll.ToWebMercator()
+		function := fmt.Sprintf("%#v.ToWebMercator()", test.ll)
+		got := fmt.Sprintf("%s %v", wm, err)
+		want := fmt.Sprintf("%s %v", test.wm, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+		if err != nil {
+			continue
+		}
+
+		ll, err := wm.ToLL()
+		function = fmt.Sprintf("%#v.ToLL()", wm)
+		got = fmt.Sprintf("%s %v", ll, err)
+		want = fmt.Sprintf("%s %v", test.ll, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+
+	// negative tests
+	_, err := WebMercator{X: 20037509, Y: 0}.ToLL()
+	got := fmt.Sprintf("%v", err)
+	want := "invalid web mercator coordinate, wm = 20037509.00 0.00"
+	if got != want {
+		t.Errorf("\nWebMercator.ToLL() -> %s != %s\n", got, want)
+	}
+}
+
+func TestLL_ToTile(t *testing.T) {
+
+	var tests = []struct {
+		ll      LL          // in
+		zoom    int         // in
+		tile    Tile        // out
+		quadkey Quadkey     // out
+		bbox    BoundingBox // out
+		err     error       // out
+	}{
+		// positive tests
+		{LL{Lat: 52.52, Lon: 13.405}, 10, Tile{Z: 10, X: 550, Y: 335}, "1202102332", BoundingBox{South: 52.482780, West: 13.359375, North: 52.696361, East: 13.710938}, nil},
+		{LL{Lat: -33.8675, Lon: 151.207}, 10, Tile{Z: 10, X: 942, Y: 614}, "3112301330", BoundingBox{South: -34.016242, West: 151.171875, North: -33.724340, East: 151.523438}, nil},
+		{LL{Lat: 0, Lon: 0}, 10, Tile{Z: 10, X: 512, Y: 512}, "3000000000", BoundingBox{South: -0.351560, West: 0, North: 0, East: 0.351562}, nil},
+		{LL{Lat: 0, Lon: 0}, 0, Tile{Z: 0, X: 0, Y: 0}, "", BoundingBox{South: -85.051129, West: -180, North: 85.051129, East: 180}, nil},
+		{LL{Lat: 85.05112877980659, Lon: 180}, 10, Tile{Z: 10, X: 0, Y: 0}, "0000000000", BoundingBox{South: 85.020708, West: -180, North: 85.051129, East: -179.648438}, nil},
+		{LL{Lat: -85.05112877980659, Lon: -180}, 10, Tile{Z: 10, X: 0, Y: 1023}, "2222222222", BoundingBox{South: -85.051129, West: -180, North: -85.020708, East: -179.648438}, nil},
+		// negative tests
+		{LL{Lat: 0, Lon: 0}, 31, Tile{}, "", BoundingBox{}, fmt.Errorf("invalid zoom level (0-30), zoom = 31")},
+		{LL{Lat: 86, Lon: 0}, 10, Tile{}, "", BoundingBox{}, fmt.Errorf("latitude outside of tile pyramid (±85.0511), lat = 86")},
+		{LL{Lat: 50, Lon: math.NaN()}, 10, Tile{}, "", BoundingBox{}, fmt.Errorf("invalid longitude, lon = NaN")},
+		{LL{Lat: math.NaN(), Lon: 10}, 10, Tile{}, "", BoundingBox{}, fmt.Errorf("latitude outside of tile pyramid (±85.0511), lat = NaN")},
+	}
+
+	for _, test := range tests {
+		tile, err := test.ll.ToTile(test.zoom)
+		function := fmt.Sprintf("%#v.ToTile(%d)", test.ll, test.zoom)
+		got := fmt.Sprintf("%s %v", tile, err)
+		want := fmt.Sprintf("%s %v", test.tile, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+		if err != nil {
+			continue
+		}
+
+		bbox, err := tile.Bounds()
+		function = fmt.Sprintf("%s.Bounds()", tile)
+		got = fmt.Sprintf("%s %v", bbox, err)
+		want = fmt.Sprintf("%s %v", test.bbox, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		quadkey, err := tile.Quadkey()
+		function = fmt.Sprintf("%s.Quadkey()", tile)
+		got = fmt.Sprintf("%q %v", quadkey, err)
+		want = fmt.Sprintf("%q %v", test.quadkey, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		tile, err = quadkey.ToTile()
+		function = fmt.Sprintf("Quadkey(%q).ToTile()", quadkey)
+		got = fmt.Sprintf("%s %v", tile, err)
+		want = fmt.Sprintf("%s %v", test.tile, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+}
+
+func TestQuadkey_ToTile(t *testing.T) {
+
+	var tests = []struct {
+		quadkey Quadkey // in
+		tile    Tile    // out
+		err     error   // out
+	}{
+		// positive tests
+		{"213", Tile{Z: 3, X: 3, Y: 5}, nil},
+		{"", Tile{Z: 0, X: 0, Y: 0}, nil},
+		// negative tests
+		{"214", Tile{}, fmt.Errorf("invalid quadkey digit '4' at position 3, quadkey = 214")},
+		{Quadkey(strings.Repeat("0", 31)), Tile{}, fmt.Errorf("invalid quadkey length (0-30), quadkey = %s", strings.Repeat("0", 31))},
+	}
+
+	for _, test := range tests {
+		tile, err := test.quadkey.ToTile()
+		function := fmt.Sprintf("Quadkey(%q).ToTile()", test.quadkey)
+		got := fmt.Sprintf("%s %v", tile, err)
+		want := fmt.Sprintf("%s %v", test.tile, test.err)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+	}
+
+	// negative tests
+	err := Tile{Z: 3, X: 8, Y: 0}.Validate()
+	got := fmt.Sprintf("%v", err)
+	want := "tile outside of tile pyramid, tile = 3/8/0"
+	if got != want {
+		t.Errorf("\nTile.Validate() -> %s != %s\n", got, want)
+	}
+}
+
+func TestTile_MGRS(t *testing.T) {
+
+	var tests = []struct {
+		tile    Tile   // in
+		zones   string // out
+		squares string // out
+	}{
+		{Tile{Z: 10, X: 550, Y: 335}, "[33U]", "[33UUU 33UVU]"},
+		{Tile{Z: 8, X: 133, Y: 86}, "[32U]", "[32ULA 32ULB 32UMA 32UMB]"},
+		{Tile{Z: 12, X: 2150, Y: 1342}, "[32U]", "[32UMD 32UND]"},
+		{Tile{Z: 7, X: 64, Y: 63}, "[31N]", "[31NAA 31NAB 31NAC 31NAD 31NBA 31NBB 31NBC 31NBD 31NCA 31NCB 31NCC 31NCD 31NDA 31NDB 31NDC 31NDD]"},
+		{Tile{Z: 4, X: 8, Y: 0}, "[31X 33X 35X Z]", "[31XDM 31XDN 31XDP 31XEM 31XEN 31XEP 33XVM 33XVN 33XVP 33XWM 33XWN 33XWP 35XMM 35XMN 35XMP ZAA ZAB ZBA ZBB ZCA ZCB]"},
+		{Tile{Z: 5, X: 16, Y: 15}, "[31N 32N 31P 32P]", ""},
+		{Tile{Z: 6, X: 33, Y: 21}, "[31U 32U]", ""},
+	}
+
+	for _, test := range tests {
+		zones, err := test.tile.MGRSGridZones()
+		function := fmt.Sprintf("%s.MGRSGridZones()", test.tile)
+		got := fmt.Sprintf("%v %v", zones, err)
+		want := fmt.Sprintf("%v %v", test.zones, nil)
+		if got != want {
+			t.Errorf("\n%s -> %s != %s\n", function, got, want)
+		}
+
+		squares, err := test.tile.MGRS100kSquares()
+		if err != nil {
+			t.Errorf("\n%s.MGRS100kSquares() -> %v\n", test.tile, err)
+			continue
+		}
+		if test.squares != "" {
+			got = fmt.Sprintf("%v", squares)
+			if got != test.squares {
+				t.Errorf("\n%s.MGRS100kSquares() -> %s != %s\n", test.tile, got, test.squares)
+			}
+		}
+
+		// every square must exist in its grid zone and belong to one of the listed zones
+		for _, square := range squares {
+			if err := square.Validate(); err != nil {
+				t.Errorf("\n%s.MGRS100kSquares() -> %s invalid: %v\n", test.tile, square, err)
+			}
+			c, _ := square.Parse()
+			if !strings.Contains(test.zones, c.GZD()) {
+				t.Errorf("\n%s.MGRS100kSquares() -> %s not in %s\n", test.tile, square, test.zones)
+			}
+		}
+	}
+
+	// negative tests
+	_, err := Tile{Z: 2, X: 4, Y: 0}.MGRS100kSquares()
+	got := fmt.Sprintf("%v", err)
+	want := "tile outside of tile pyramid, tile = 2/4/0"
+	if got != want {
+		t.Errorf("\nTile.MGRS100kSquares() -> %s != %s\n", got, want)
+	}
+}
+
+func ExampleTile_MGRS100kSquares() {
+
+	ll := LL{Lat: 52.52, Lon: 13.405}
+	tile, err := ll.ToTile(10)
+	if err != nil {
+		log.Printf("error <%v> at ll.ToTile()", err)
+		return
+	}
+	squares, err := tile.MGRS100kSquares()
+	if err != nil {
+		log.Printf("error <%v> at tile.MGRS100kSquares()", err)
+		return
+	}
+	fmt.Println(tile)
+	fmt.Println(squares)
+
+	// Output:
+	// 10/550/335
+	// [33UUU 33UVU]
+}